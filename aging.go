@@ -0,0 +1,30 @@
+package minmaxheap
+
+import "time"
+
+// AgingInterface is a heap whose elements record when they were inserted, so
+// that long-waiting elements can be boosted with BoostOlderThan.
+type AgingInterface interface {
+	Interface
+	// InsertedAt returns the insertion time of the element at index i.
+	InsertedAt(i int) time.Time
+}
+
+// BoostOlderThan calls boost for every element of h that was inserted more
+// than d ago, then re-establishes the heap invariants with a single Init.
+// boost should raise the priority of the element at index i (move it toward
+// the min end); it must not add or remove elements.
+// The complexity is O(n) where n = h.Len().
+func BoostOlderThan(h AgingInterface, d time.Duration, boost func(i int)) {
+	cutoff := time.Now().Add(-d)
+	boosted := false
+	for i, n := 0, h.Len(); i < n; i++ {
+		if h.InsertedAt(i).Before(cutoff) {
+			boost(i)
+			boosted = true
+		}
+	}
+	if boosted {
+		Init(h)
+	}
+}
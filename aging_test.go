@@ -0,0 +1,82 @@
+package minmaxheap_test
+
+import (
+	"testing"
+	"time"
+
+	heap "storj.io/minmaxheap"
+	"storj.io/minmaxheap/minmaxheaptest"
+)
+
+type task struct {
+	priority int
+	inserted time.Time
+}
+
+type taskHeap []task
+
+func (h taskHeap) Len() int                   { return len(h) }
+func (h taskHeap) Less(i, j int) bool         { return h[i].priority < h[j].priority }
+func (h taskHeap) Swap(i, j int)              { h[i], h[j] = h[j], h[i] }
+func (h taskHeap) InsertedAt(i int) time.Time { return h[i].inserted }
+
+func (h *taskHeap) Push(x interface{}) {
+	*h = append(*h, x.(task))
+}
+
+func (h *taskHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+func TestBoostOlderThan(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-time.Hour)
+	h := &taskHeap{{100, old}, {10, now}, {50, now}, {60, old}, {10, now}}
+	heap.Init(h)
+
+	heap.BoostOlderThan(h, time.Minute, func(i int) {
+		(*h)[i].priority -= 95
+	})
+	if err := heap.Verify(h); err != nil {
+		t.Fatal(err)
+	}
+	minmaxheaptest.AssertDrainsTo(t, h, []interface{}{
+		task{-35, old}, task{5, old}, task{10, now}, task{10, now}, task{50, now},
+	})
+}
+
+func TestBoostOlderThanStarvation(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-time.Hour)
+	h := new(taskHeap)
+	heap.Push(h, task{priority: 100, inserted: old})
+
+	// fresh, high-priority work keeps arriving; the old task drops by 25 a
+	// pass, 75, 50, 25 and then 0, when it finally beats the fresh work
+	var want []interface{}
+	for pass := 1; pass <= 4; pass++ {
+		for i := 0; i < 5; i++ {
+			heap.Push(h, task{priority: 10, inserted: now})
+			want = append(want, task{10, now})
+		}
+
+		heap.BoostOlderThan(h, time.Minute, func(i int) {
+			(*h)[i].priority -= 25
+		})
+
+		wantTop := task{10, now}
+		if pass == 4 {
+			wantTop = task{0, old}
+		} else {
+			want = want[1:]
+		}
+		if top := heap.Pop(h).(task); top != wantTop {
+			t.Fatalf("pass %d: popped %v; want %v", pass, top, wantTop)
+		}
+	}
+	minmaxheaptest.AssertDrainsTo(t, h, want)
+}
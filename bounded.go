@@ -0,0 +1,40 @@
+package minmaxheap
+
+// Bounded keeps the largest elements offered to it, up to a fixed capacity,
+// in an underlying heap. Once the heap is full, offering an element larger
+// than the current minimum evicts that minimum.
+type Bounded struct {
+	h        Interface
+	capacity int
+}
+
+// NewBounded returns a Bounded that keeps at most capacity elements in h.
+// The heap h must satisfy the heap invariants and is used in place; if it
+// already holds more than capacity elements, Offer evicts the minimum on each
+// call but does not shrink it further. A capacity of zero or less retains
+// nothing: Offer always returns false and leaves h unchanged.
+func NewBounded(h Interface, capacity int) *Bounded {
+	return &Bounded{h: h, capacity: capacity}
+}
+
+// Offer adds x to the heap if there is room, or if x is greater than the
+// current minimum, which is then evicted. It reports whether x was retained.
+// The complexity is O(log n) where n = h.Len().
+func (b *Bounded) Offer(x interface{}) bool {
+	if b.capacity <= 0 {
+		return false
+	}
+	h := b.h
+	if h.Len() < b.capacity {
+		Push(h, x)
+		return true
+	}
+	_, kept := pushPop(h, x)
+	return kept
+}
+
+// Len returns the number of elements in the underlying heap.
+func (b *Bounded) Len() int { return b.h.Len() }
+
+// Cap returns the capacity b was created with.
+func (b *Bounded) Cap() int { return b.capacity }
@@ -0,0 +1,45 @@
+package minmaxheap
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestBounded(t *testing.T) {
+	rng := newTestRand(t)
+
+	const k = 20
+	h := new(myHeap)
+	b := NewBounded(h, k)
+	var all []int
+	for i := 0; i < 1000; i++ {
+		x := rng.Intn(500)
+		all = append(all, x)
+		want := b.Len() < k || x > (*h)[0]
+		if kept := b.Offer(x); kept != want {
+			t.Fatalf("Offer(%d) = %v; want %v", x, kept, want)
+		}
+		h.verify(t, 0)
+	}
+	if b.Len() != k || b.Cap() != k {
+		t.Fatalf("Len() = %d, Cap() = %d; want %d", b.Len(), b.Cap(), k)
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(all)))
+	for i := k - 1; i >= 0; i-- {
+		if x := Pop(h).(int); x != all[i] {
+			t.Fatalf("kept element %d = %d; want %d", i, x, all[i])
+		}
+	}
+}
+
+func TestBoundedZeroCapacity(t *testing.T) {
+	h := &myHeap{1, 5, 3}
+	b := NewBounded(h, 0)
+	if b.Offer(10) {
+		t.Error("Offer on a zero capacity Bounded returned true")
+	}
+	if h.Len() != 3 {
+		t.Errorf("Len() = %d; want 3", h.Len())
+	}
+}
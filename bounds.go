@@ -0,0 +1,95 @@
+package minmaxheap
+
+import "fmt"
+
+// MaxComparisons returns the largest number of calls to Less that the
+// operation op makes on a heap of n elements, where op is "Push", "Pop" or
+// "PopMax". For Push, n is the length before the push. It panics for any
+// other op.
+//
+// Push compares with the parent and then with every second ancestor, so it
+// makes at most 1 + floor(log2(n+1))/2 comparisons. Pop and PopMax sift down
+// two levels at a time, comparing with up to four grandchildren and then with
+// the parent of the chosen one; PopMax first makes up to two comparisons to
+// find the maximum. The bound is computed from the shape of the heap, and is
+// reached for some arrangement of elements.
+func MaxComparisons(op string, n int) int {
+	switch op {
+	case "Push":
+		if n <= 0 {
+			return 0
+		}
+		return 1 + level(n)/2
+	case "Pop":
+		if n <= 0 {
+			return 0
+		}
+		return maxDownComparisons(n-1, map[int]int{})
+	case "PopMax":
+		if n <= 0 {
+			return 0
+		}
+		find := n - 1
+		if find > 2 {
+			find = 2
+		}
+		memo := map[int]int{}
+		worst := 0
+		for i := lchild(0); i <= rchild(0) && i < n-1; i++ {
+			if c := maxDownComparisons(subtreeSize(i, n-1), memo); c > worst {
+				worst = c
+			}
+		}
+		return find + worst
+	default:
+		panic(fmt.Sprintf("minmaxheap: MaxComparisons of unknown op %q", op))
+	}
+}
+
+// maxDownComparisons returns the largest number of comparisons down makes
+// starting at the root of a heap of n elements. Every subtree of a heap has
+// the shape of a heap of its size, so results are memoized by size.
+func maxDownComparisons(n int, memo map[int]int) int {
+	l, r := lchild(0), rchild(0)
+	if l >= n {
+		return 0
+	}
+	if c, ok := memo[n]; ok {
+		return c
+	}
+
+	c := 0
+	// children are only compared when they are leaves, see down.
+	if subtreeSize(l, n) == 1 {
+		c++
+	}
+	if r < n && subtreeSize(r, n) == 1 {
+		c++
+	}
+	worst := -1
+	for g := lchild(l); g < n && g <= rchild(r); g++ {
+		c++
+		if w := maxDownComparisons(subtreeSize(g, n), memo); w > worst {
+			worst = w
+		}
+	}
+	if worst >= 0 {
+		// compare with the grandchild's parent, then continue from it
+		c += 1 + worst
+	}
+	memo[n] = c
+	return c
+}
+
+// subtreeSize returns the number of elements in the subtree rooted at i of a
+// heap of n elements.
+func subtreeSize(i, n int) int {
+	size := 0
+	for lo, hi := i, i; lo < n; lo, hi = lchild(lo), rchild(hi) {
+		if hi >= n {
+			hi = n - 1
+		}
+		size += hi - lo + 1
+	}
+	return size
+}
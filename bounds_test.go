@@ -0,0 +1,73 @@
+package minmaxheap
+
+import "testing"
+
+func TestMaxComparisons(t *testing.T) {
+	rng := newTestRand(t)
+
+	for _, op := range []string{"Push", "Pop", "PopMax"} {
+		for n := 0; n <= 300; n++ {
+			bound := MaxComparisons(op, n)
+			worst := 0
+			for trial := 0; trial < 50; trial++ {
+				h := new(countingHeap)
+				for i := 0; i < n; i++ {
+					h.myHeap = append(h.myHeap, rng.Intn(n+1))
+				}
+				Init(h)
+
+				h.less = 0
+				switch {
+				case op == "Push":
+					Push(h, rng.Intn(n+1))
+				case n == 0:
+					continue
+				case op == "Pop":
+					Pop(h)
+				default:
+					PopMax(h)
+				}
+				if h.less > bound {
+					t.Fatalf("%s on %d elements made %d comparisons; MaxComparisons = %d", op, n, h.less, bound)
+				}
+				if h.less > worst {
+					worst = h.less
+				}
+			}
+			if bound > 0 && worst == 0 {
+				t.Errorf("%s on %d elements never compared", op, n)
+			}
+		}
+	}
+}
+
+func TestMaxComparisonsSmall(t *testing.T) {
+	for _, tc := range []struct {
+		op   string
+		n    int
+		want int
+	}{
+		{"Push", 0, 0},
+		{"Push", 1, 1},
+		{"Push", 3, 2},
+		{"Pop", 1, 0},
+		{"Pop", 4, 2}, // both children are leaves
+		{"Pop", 8, 5}, // four grandchildren, then the parent
+		{"PopMax", 1, 0},
+		{"PopMax", 2, 1},
+		{"PopMax", 3, 2},
+	} {
+		if got := MaxComparisons(tc.op, tc.n); got != tc.want {
+			t.Errorf("MaxComparisons(%q, %d) = %d; want %d", tc.op, tc.n, got, tc.want)
+		}
+	}
+}
+
+func TestMaxComparisonsUnknown(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MaxComparisons of unknown op did not panic")
+		}
+	}()
+	MaxComparisons("Fix", 10)
+}
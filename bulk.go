@@ -0,0 +1,133 @@
+package minmaxheap
+
+// Retain removes every element for which keep returns false and returns the
+// number of elements removed. keep is called once for each index, in
+// increasing order, with the elements not yet visited still in place. The
+// kept elements are compacted to the front in a single pass, the rest are
+// popped off the end, and the heap is rebuilt with one Init.
+// The complexity is O(n) where n = h.Len().
+func Retain(h Interface, keep func(i int) bool) int {
+	n := h.Len()
+	w := 0
+	for r := 0; r < n; r++ {
+		if keep(r) {
+			if r != w {
+				h.Swap(w, r)
+			}
+			w++
+		}
+	}
+	for i := w; i < n; i++ {
+		h.Pop()
+	}
+	Init(h)
+	return n - w
+}
+
+// PushSorted pushes the elements of sorted, which must be in ascending order,
+// onto h. It is PushSlice under another name: a large batch is appended and
+// only its ancestors are re-heapified, avoiding the O(m log n) cost of pushing
+// the elements one at a time, while a batch that is tiny relative to the heap
+// is pushed one at a time.
+//
+// Being sorted does not help beyond that: an ascending run is a valid
+// ordinary heap but not a valid min-max heap, since max levels must hold the
+// larger elements, so the appended elements still need to be heapified.
+// The complexity is that of PushSlice.
+func PushSorted(h Interface, sorted []interface{}) {
+	PushSlice(h, sorted)
+}
+
+// Merge pushes every element of src onto dst, leaving src unchanged. The
+// elements are appended and dst is rebuilt with one Init, so when dst is empty
+// this is equivalent to building a heap from a copy of src. If src implements
+// Cloner, Merge melds a clone of it into dst. Otherwise src is read by popping
+// its elements off the end and pushing them back in reverse order, which
+// restores its exact arrangement. dst and src must be different heaps.
+//
+// As with PushSlice, pushing the elements one at a time can be faster when
+// they are spread like dst's, since Push is then much cheaper than its
+// O(log n) worst case. Merge pays off when src is large and its elements tend
+// to be larger than dst's; see BenchmarkMerge.
+// The complexity is O(n+m) where n = dst.Len() and m = src.Len().
+func Merge(dst, src Interface) {
+	if c := Clone(src); c != nil {
+		Meld(dst, c)
+		return
+	}
+	m := src.Len()
+	xs := make([]interface{}, m)
+	for i := m - 1; i >= 0; i-- {
+		xs[i] = src.Pop()
+	}
+	for _, x := range xs {
+		src.Push(x)
+		dst.Push(x)
+	}
+	Init(dst)
+}
+
+// Meld is like Merge, but moves the elements instead of copying them, leaving
+// src empty. It avoids the copy Merge needs to keep src intact.
+// The complexity is O(n+m) where n = dst.Len() and m = src.Len().
+func Meld(dst, src Interface) {
+	for src.Len() > 0 {
+		dst.Push(src.Pop())
+	}
+	Init(dst)
+}
+
+// PushSlice pushes the elements of xs onto h. The elements are appended and
+// only their ancestors are sifted down, level by level from the bottom, as
+// Init would but skipping the subtrees the batch did not touch. A batch that
+// is tiny relative to the heap is pushed one element at a time instead.
+//
+// As with Merge, the bulk path wins clearly when the new elements tend to be
+// larger than the heap's and would each climb to the max levels, but can be
+// slower than pushing one at a time when they are spread like the heap's; see
+// BenchmarkPushSlice.
+// The complexity is O(m + log² n) where n = h.Len() and m = len(xs), or
+// O(m log n) for a tiny batch.
+func PushSlice(h Interface, xs []interface{}) {
+	n := h.Len()
+	if len(xs)*pushSliceRatio < n {
+		for _, x := range xs {
+			Push(h, x)
+		}
+		return
+	}
+	for _, x := range xs {
+		h.Push(x)
+	}
+	heapifyTail(h, n)
+}
+
+// pushSliceRatio is how many times larger than a batch the heap must be for
+// PushSlice to push the batch one element at a time.
+const pushSliceRatio = 16
+
+// heapifyTail re-establishes the heap invariants of h when only the elements
+// from index lo on may violate them.
+//
+// It sifts down the ancestors of [lo, n) one level at a time, bottom up. With
+// m = n-lo, the range of ancestors k levels up spans at most m/2^k + 2 nodes,
+// and sifting down from there costs O(k). Summed over the O(log n) levels,
+// the m/2^k terms give O(m) and the two nodes at either end of each range
+// give O(log² n), for O(m + log² n) in total.
+func heapifyTail(h Interface, lo int) {
+	n := h.Len()
+	if lo >= n || n < 2 {
+		return
+	}
+	if lo == 0 {
+		Init(h)
+		return
+	}
+	hi := n - 1
+	for hi > 0 {
+		lo, hi = parent(lo), parent(hi)
+		for i := hi; i >= lo; i-- {
+			down(h, i, n)
+		}
+	}
+}
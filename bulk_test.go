@@ -0,0 +1,271 @@
+package minmaxheap
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestRetain(t *testing.T) {
+	rng := newTestRand(t)
+
+	h := new(myHeap)
+	for i := 0; i < 200; i++ {
+		Push(h, rng.Intn(100))
+	}
+	want := 0
+	for _, x := range *h {
+		if x%3 == 0 {
+			want++
+		}
+	}
+
+	removed := Retain(h, func(i int) bool { return (*h)[i]%3 == 0 })
+	if removed != 200-want || h.Len() != want {
+		t.Fatalf("Retain removed %d, Len %d; want %d, %d", removed, h.Len(), 200-want, want)
+	}
+	for _, x := range *h {
+		if x%3 != 0 {
+			t.Fatalf("Retain kept %d", x)
+		}
+	}
+	h.verify(t, 0)
+
+	if removed := Retain(h, func(int) bool { return false }); removed != want || h.Len() != 0 {
+		t.Fatalf("Retain(none) removed %d, Len %d", removed, h.Len())
+	}
+}
+
+func BenchmarkRetain(b *testing.B) {
+	const n = 100_000
+	h := make(myHeap, 0, n)
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		h = h[:0]
+		for j := 0; j < n; j++ {
+			h = append(h, j)
+		}
+		Init(&h)
+		b.StartTimer()
+
+		Retain(&h, func(i int) bool { return h[i]%10 == 0 }) // remove 90%
+	}
+}
+
+func TestPushSorted(t *testing.T) {
+	h := &myHeap{50, 10, 30}
+	Init(h)
+
+	var sorted []interface{}
+	for i := 0; i < 100; i++ {
+		sorted = append(sorted, i)
+	}
+	PushSorted(h, sorted)
+	if h.Len() != 103 {
+		t.Fatalf("Len() = %d; want 103", h.Len())
+	}
+	h.verify(t, 0)
+
+	// a batch tiny relative to the heap is pushed one at a time
+	PushSorted(h, []interface{}{-1, 200})
+	if h.Len() != 105 {
+		t.Fatalf("Len() = %d; want 105", h.Len())
+	}
+	h.verify(t, 0)
+	if lo, hi := PeekMin(h).(int), PeekMax(h).(int); lo != -1 || hi != 200 {
+		t.Errorf("PeekMin, PeekMax = %d, %d; want -1, 200", lo, hi)
+	}
+}
+
+func BenchmarkPushSorted(b *testing.B) {
+	const m = 100_000
+	sorted := make([]interface{}, m)
+	for i := range sorted {
+		sorted[i] = 2 * i
+	}
+
+	for _, n := range []int{m / 100, m} {
+		fill := func(h *myHeap) {
+			*h = (*h)[:0]
+			for j := 0; j < n; j++ {
+				*h = append(*h, 2*m*j/n+1)
+			}
+			Init(h)
+		}
+
+		b.Run(fmt.Sprintf("PushSorted/%d", n), func(b *testing.B) {
+			h := make(myHeap, 0, n+m)
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				fill(&h)
+				b.StartTimer()
+
+				PushSorted(&h, sorted)
+			}
+		})
+		b.Run(fmt.Sprintf("Push/%d", n), func(b *testing.B) {
+			h := make(myHeap, 0, n+m)
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				fill(&h)
+				b.StartTimer()
+
+				for _, x := range sorted {
+					Push(&h, x)
+				}
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	rng := newTestRand(t)
+
+	for _, size := range [][2]int{{0, 0}, {0, 20}, {20, 0}, {1, 1}, {50, 200}, {200, 50}} {
+		dst, src := new(myHeap), new(myHeap)
+		for i := 0; i < size[0]; i++ {
+			Push(dst, rng.Intn(100))
+		}
+		for i := 0; i < size[1]; i++ {
+			Push(src, rng.Intn(100))
+		}
+		want := append(append(myHeap(nil), *dst...), *src...)
+		sort.Ints(want)
+		before := append(myHeap(nil), *src...)
+
+		Merge(dst, src)
+		if !slices.Equal(*src, before) {
+			t.Fatalf("%v: Merge changed src to %v; want %v", size, *src, before)
+		}
+		dst.verify(t, 0)
+		melded := append(myHeap(nil), *dst...)
+		for _, x := range want {
+			if y := Pop(dst).(int); y != x {
+				t.Fatalf("%v: merged heap popped %d; want %d", size, y, x)
+			}
+		}
+
+		Meld(&melded, src)
+		if src.Len() != 0 {
+			t.Fatalf("%v: Len() = %d after Meld; want 0", size, src.Len())
+		}
+		if melded.Len() != len(want)+len(before) {
+			t.Fatalf("%v: Len() = %d after Meld; want %d", size, melded.Len(), len(want)+len(before))
+		}
+		melded.verify(t, 0)
+	}
+}
+
+func BenchmarkMerge(b *testing.B) {
+	const n = 100_000
+	dst := make(myHeap, 0, 2*n)
+	fill := func() {
+		dst = dst[:0]
+		for j := 0; j < n; j++ {
+			dst = append(dst, (j*104729)%n)
+		}
+		Init(&dst)
+	}
+
+	for _, tc := range []struct {
+		name string
+		elem func(j int) int
+	}{
+		// src spans the same range as dst
+		{"mixed", func(j int) int { return (j * 7919) % n }},
+		// src ascends above dst, so that each pushed element climbs to the
+		// top max level; Merge does not depend on src's arrangement
+		{"ascending", func(j int) int { return n + j }},
+	} {
+		src := make(myHeap, n)
+		for j := range src {
+			src[j] = tc.elem(j)
+		}
+		if tc.name == "mixed" {
+			Init(&src)
+		}
+
+		b.Run("Merge/"+tc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				fill()
+				b.StartTimer()
+
+				Merge(&dst, &src)
+			}
+		})
+		b.Run("Meld/"+tc.name, func(b *testing.B) {
+			s := make(myHeap, 0, n)
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				fill()
+				s = append(s[:0], src...)
+				b.StartTimer()
+
+				Meld(&dst, &s)
+			}
+		})
+		b.Run("Push/"+tc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				fill()
+				b.StartTimer()
+
+				for _, x := range src {
+					Push(&dst, x)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkPushSlice(b *testing.B) {
+	const n = 100_000
+	h := make(myHeap, 0, 2*n)
+	fill := func() {
+		h = h[:0]
+		for j := 0; j < n; j++ {
+			h = append(h, (j*104729)%n)
+		}
+		Init(&h)
+	}
+
+	for _, tc := range []struct {
+		name string
+		elem func(j int) int
+	}{
+		{"mixed", func(j int) int { return (j * 7919) % n }},
+		{"ascending", func(j int) int { return n + j }},
+	} {
+		// Batches smaller than n/pushSliceRatio take the Push loop inside
+		// PushSlice; larger ones are appended and heapified.
+		for _, m := range []int{n / 64, n / 4, n} {
+			xs := make([]interface{}, m)
+			for j := range xs {
+				xs[j] = tc.elem(j)
+			}
+
+			b.Run(fmt.Sprintf("PushSlice/%s/%d", tc.name, m), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					fill()
+					b.StartTimer()
+
+					PushSlice(&h, xs)
+				}
+			})
+			b.Run(fmt.Sprintf("Push/%s/%d", tc.name, m), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					fill()
+					b.StartTimer()
+
+					for _, x := range xs {
+						Push(&h, x)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package minmaxheap
+
+import "bytes"
+
+// BytesHeap is a min-max heap of byte slices ordered by bytes.Compare. Use a
+// *BytesHeap with the functions in this package.
+//
+// Push stores the slice it is given, not a copy, so the caller must not
+// modify a slice after pushing it. Pop clears the slot it vacates, so the
+// backing array does not keep popped slices reachable.
+type BytesHeap [][]byte
+
+// Len implements Interface.
+func (h BytesHeap) Len() int { return len(h) }
+
+// Less implements Interface.
+func (h BytesHeap) Less(i, j int) bool { return bytes.Compare(h[i], h[j]) < 0 }
+
+// Swap implements Interface.
+func (h BytesHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+// Push implements Interface. x must be a []byte.
+func (h *BytesHeap) Push(x interface{}) {
+	*h = append(*h, x.([]byte))
+}
+
+// Pop implements Interface.
+func (h *BytesHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return x
+}
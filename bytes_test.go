@@ -0,0 +1,83 @@
+package minmaxheap
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func TestBytesHeapMergeRuns(t *testing.T) {
+	rng := newTestRand(t)
+
+	// several sorted runs, as from a compaction
+	var runs [][][]byte
+	var all [][]byte
+	for r := 0; r < 5; r++ {
+		var run [][]byte
+		for i := 0; i < 20; i++ {
+			run = append(run, []byte(fmt.Sprintf("key-%03d", rng.Intn(100))))
+		}
+		sort.Slice(run, func(i, j int) bool { return bytes.Compare(run[i], run[j]) < 0 })
+		runs = append(runs, run)
+		all = append(all, run...)
+	}
+	sort.Slice(all, func(i, j int) bool { return bytes.Compare(all[i], all[j]) < 0 })
+
+	// k-way merge: the heap holds one head per run, tagged by its run index
+	type head struct {
+		run, pos int
+	}
+	h := new(BytesHeap)
+	heads := map[string][]head{}
+	push := func(run, pos int) {
+		key := runs[run][pos]
+		heads[string(key)] = append(heads[string(key)], head{run, pos})
+		Push(h, key)
+	}
+	for r := range runs {
+		push(r, 0)
+	}
+
+	var merged [][]byte
+	for h.Len() > 0 {
+		key := Pop(h).([]byte)
+		merged = append(merged, key)
+
+		hs := heads[string(key)]
+		next := hs[0]
+		heads[string(key)] = hs[1:]
+		if next.pos+1 < len(runs[next.run]) {
+			push(next.run, next.pos+1)
+		}
+	}
+
+	if len(merged) != len(all) {
+		t.Fatalf("merged %d keys; want %d", len(merged), len(all))
+	}
+	for i := range all {
+		if !bytes.Equal(merged[i], all[i]) {
+			t.Fatalf("merged[%d] = %q; want %q", i, merged[i], all[i])
+		}
+	}
+}
+
+func TestBytesHeapPopClears(t *testing.T) {
+	h := &BytesHeap{[]byte("b"), []byte("a"), []byte("c")}
+	Init(h)
+
+	backing := (*h)[:3]
+	if x := PopMax(h).([]byte); string(x) != "c" {
+		t.Fatalf("PopMax = %q; want c", x)
+	}
+	if backing[2] != nil {
+		t.Errorf("Pop left %q in the vacated slot", backing[2])
+	}
+
+	// Swap exchanges slice headers, not contents
+	a, b := (*h)[0], (*h)[1]
+	h.Swap(0, 1)
+	if &(*h)[0][0] != &b[0] || &(*h)[1][0] != &a[0] {
+		t.Error("Swap did not exchange the slices")
+	}
+}
@@ -0,0 +1,39 @@
+package minmaxheap
+
+// SortedCursor reads a snapshot of a heap in ascending order, a page at a
+// time. The snapshot is taken when the cursor is created, so later changes to
+// the heap do not affect it.
+type SortedCursor struct {
+	sorted []interface{}
+	pos    int
+}
+
+// NewSortedCursor returns a cursor over the elements of h. If h implements
+// Cloner, the snapshot is taken from a clone and h is left untouched;
+// otherwise h keeps its elements, though their arrangement may change.
+// The complexity is O(n log n) where n = h.Len().
+func NewSortedCursor(h Interface) *SortedCursor {
+	return &SortedCursor{sorted: sortedCopy(h)}
+}
+
+// Next returns the next n elements in ascending order, or fewer if the
+// snapshot has fewer left. It returns an empty slice once the snapshot is
+// exhausted. The returned slice aliases the snapshot and must not be
+// modified.
+func (c *SortedCursor) Next(n int) []interface{} {
+	if n < 0 {
+		n = 0
+	}
+	end := c.pos + n
+	if end > len(c.sorted) {
+		end = len(c.sorted)
+	}
+	page := c.sorted[c.pos:end:end]
+	c.pos = end
+	return page
+}
+
+// Remaining returns the number of elements not yet returned by Next.
+func (c *SortedCursor) Remaining() int {
+	return len(c.sorted) - c.pos
+}
@@ -0,0 +1,52 @@
+package minmaxheap
+
+import (
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestSortedCursor(t *testing.T) {
+	rng := newTestRand(t)
+
+	const n = 1_000
+	h := new(myHeap)
+	for i := 0; i < n; i++ {
+		Push(h, rng.Intn(n))
+	}
+	want := append([]int(nil), *h...)
+	sort.Ints(want)
+
+	before := append(myHeap(nil), *h...)
+	c := NewSortedCursor(h)
+	if !slices.Equal(*h, before) {
+		t.Fatal("NewSortedCursor rearranged a Cloner")
+	}
+
+	// changes to the heap after creation are not seen
+	Push(h, -1)
+
+	var got []int
+	for c.Remaining() > 0 {
+		before := c.Remaining()
+		page := c.Next(37)
+		if len(page) != 37 && len(page) != before {
+			t.Fatalf("Next(37) returned %d of %d remaining", len(page), before)
+		}
+		for _, x := range page {
+			got = append(got, x.(int))
+		}
+	}
+	if len(c.Next(10)) != 0 {
+		t.Fatal("Next after exhaustion returned elements")
+	}
+
+	if len(got) != n {
+		t.Fatalf("paged %d elements; want %d", len(got), n)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("element %d = %d; want %d", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package minmaxheap
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Digest estimates quantiles of an unbounded stream of values using a fixed
+// amount of memory.
+//
+// Every value added is given a uniformly random tag, and the Digest keeps the
+// size values with the smallest tags in a min-max heap ordered by tag, evicting
+// the largest tag when a smaller one arrives. The retained values are thus a
+// uniform random sample, without replacement, of everything added so far.
+//
+// Quantile answers over that sample, so its error depends only on size and
+// not on the length of the stream: the rank of the returned value is off by
+// about sqrt(p*(1-p)/size) (one standard deviation) as a fraction of the
+// stream. With size 1000 the median is typically within 1.6% of the true
+// median's rank.
+type Digest struct {
+	size    int
+	rng     *rand.Rand
+	count   int
+	samples sampleHeap
+}
+
+// NewDigest returns a Digest that retains at most size values. Tags are drawn
+// from rng, or from the math/rand package-level source if rng is nil.
+func NewDigest(size int, rng *rand.Rand) *Digest {
+	return &Digest{
+		size:    size,
+		rng:     rng,
+		samples: make(sampleHeap, 0, size),
+	}
+}
+
+// Add adds x to the stream.
+// The complexity is O(log size).
+func (d *Digest) Add(x float64) {
+	d.count++
+	if d.size <= 0 {
+		return
+	}
+
+	var tag float64
+	if d.rng != nil {
+		tag = d.rng.Float64()
+	} else {
+		tag = rand.Float64()
+	}
+
+	s := sample{tag: tag, value: x}
+	if d.samples.Len() < d.size {
+		Push(&d.samples, s)
+		return
+	}
+	i := maxIndex(&d.samples)
+	if tag < d.samples[i].tag {
+		d.samples[i] = s
+		Fix(&d.samples, i)
+	}
+}
+
+// Count returns the number of values added to the stream.
+func (d *Digest) Count() int {
+	return d.count
+}
+
+// Quantile returns an estimate of the p-quantile of the stream, for p in
+// [0, 1]; p outside that range is clamped. It returns NaN if no values have
+// been retained.
+// The complexity is O(size log size).
+func (d *Digest) Quantile(p float64) float64 {
+	n := len(d.samples)
+	if n == 0 {
+		return math.NaN()
+	}
+	values := make([]float64, n)
+	for i, s := range d.samples {
+		values[i] = s.value
+	}
+	sort.Float64s(values)
+
+	i := int(math.Ceil(p*float64(n))) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= n {
+		i = n - 1
+	}
+	return values[i]
+}
+
+type sample struct {
+	tag   float64
+	value float64
+}
+
+type sampleHeap []sample
+
+func (h sampleHeap) Len() int           { return len(h) }
+func (h sampleHeap) Less(i, j int) bool { return h[i].tag < h[j].tag }
+func (h sampleHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *sampleHeap) Push(x interface{}) {
+	*h = append(*h, x.(sample))
+}
+
+func (h *sampleHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
@@ -0,0 +1,73 @@
+package minmaxheap
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestDigestQuantile(t *testing.T) {
+	const (
+		size = 1000
+		n    = 100_000
+	)
+
+	for _, dist := range []struct {
+		name     string
+		gen      func(*rand.Rand) float64
+		quantile func(p float64) float64
+		density  func(p float64) float64 // at the p-quantile
+	}{
+		{
+			name:     "uniform",
+			gen:      (*rand.Rand).Float64,
+			quantile: func(p float64) float64 { return p },
+			density:  func(p float64) float64 { return 1 },
+		},
+		{
+			name:     "exponential",
+			gen:      (*rand.Rand).ExpFloat64,
+			quantile: func(p float64) float64 { return -math.Log(1 - p) },
+			density:  func(p float64) float64 { return 1 - p },
+		},
+	} {
+		t.Run(dist.name, func(t *testing.T) {
+			rng := newTestRand(t)
+			d := NewDigest(size, rng)
+			for i := 0; i < n; i++ {
+				d.Add(dist.gen(rng))
+			}
+			if d.Count() != n {
+				t.Fatalf("Count() = %d; want %d", d.Count(), n)
+			}
+
+			for _, p := range []float64{0.5, 0.9, 0.99} {
+				// allow six standard deviations of rank error
+				tol := 6 * math.Sqrt(p*(1-p)/size) / dist.density(p)
+				got, want := d.Quantile(p), dist.quantile(p)
+				if math.Abs(got-want) > tol {
+					t.Errorf("Quantile(%v) = %v; want %v ± %v", p, got, want, tol)
+				}
+			}
+		})
+	}
+}
+
+func TestDigestSmall(t *testing.T) {
+	d := NewDigest(10, newTestRand(t))
+	if q := d.Quantile(0.5); !math.IsNaN(q) {
+		t.Errorf("Quantile of empty digest = %v; want NaN", q)
+	}
+
+	// fewer values than the sample size are all retained
+	for _, x := range []float64{5, 1, 4, 2, 3} {
+		d.Add(x)
+	}
+	for _, tc := range []struct{ p, want float64 }{
+		{0, 1}, {0.2, 1}, {0.5, 3}, {0.9, 5}, {1, 5}, {2, 5},
+	} {
+		if got := d.Quantile(tc.p); got != tc.want {
+			t.Errorf("Quantile(%v) = %v; want %v", tc.p, got, tc.want)
+		}
+	}
+}
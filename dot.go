@@ -0,0 +1,46 @@
+package minmaxheap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormatDOT returns a Graphviz digraph of h as a tree, with one node per
+// element labeled with its value, formatted with %v, and its index, colored
+// like FormatSVG by min or max level, and an edge from each element to its
+// children. Unlike FormatSVG it includes every element, leaving the layout of
+// large heaps to Graphviz.
+//
+// As with FormatSVG, values are read through Pop and Push unless h implements
+// Indexer. It need not be a valid heap.
+// The complexity is O(n) where n = h.Len().
+func FormatDOT(h Interface) string {
+	var buf strings.Builder
+	buf.WriteString("digraph minmaxheap {\n")
+	buf.WriteString("  node [shape=circle, style=filled];\n")
+	n := h.Len()
+	for i := 0; i < n; i++ {
+		color := "lightblue"
+		if !isMinLevel(i) {
+			color = "lightpink"
+		}
+		fmt.Fprintf(&buf, "  n%d [label=\"%s\\n[%d]\", fillcolor=%s];\n",
+			i, dotEscape(fmt.Sprint(element(h, i))), i, color)
+	}
+	for i := 0; i < n; i++ {
+		for _, c := range []int{lchild(i), rchild(i)} {
+			if c < n {
+				fmt.Fprintf(&buf, "  n%d -> n%d;\n", i, c)
+			}
+		}
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// dotEscape escapes s for use inside a double-quoted DOT string.
+func dotEscape(s string) string {
+	return dotEscaper.Replace(s)
+}
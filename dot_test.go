@@ -0,0 +1,47 @@
+package minmaxheap
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFormatDOT(t *testing.T) {
+	h := &myHeap{1, 9, 8, 2}
+	before := append(myHeap(nil), *h...)
+
+	got := FormatDOT(h)
+	want := `digraph minmaxheap {
+  node [shape=circle, style=filled];
+  n0 [label="1\n[0]", fillcolor=lightblue];
+  n1 [label="9\n[1]", fillcolor=lightpink];
+  n2 [label="8\n[2]", fillcolor=lightpink];
+  n3 [label="2\n[3]", fillcolor=lightblue];
+  n0 -> n1;
+  n0 -> n2;
+  n1 -> n3;
+}
+`
+	if got != want {
+		t.Errorf("FormatDOT =\n%s\nwant\n%s", got, want)
+	}
+	if !slices.Equal(*h, before) {
+		t.Errorf("FormatDOT changed the heap to %v", *h)
+	}
+
+	if got, want := FormatDOT(&stringHeap{`a"b\c`}), "digraph minmaxheap {\n  node [shape=circle, style=filled];\n  n0 [label=\"a\\\"b\\\\c\\n[0]\", fillcolor=lightblue];\n}\n"; got != want {
+		t.Errorf("FormatDOT with quotes =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatDOTLarge(t *testing.T) {
+	h := new(myHeap)
+	for i := 0; i < 1000; i++ {
+		Push(h, i)
+	}
+	dot := FormatDOT(h)
+	// every element gets a node line and every element but the root an edge
+	if got := strings.Count(dot, "\n"); got != 2*1000-1+3 {
+		t.Errorf("FormatDOT of 1000 elements has %d lines; want %d", got, 2*1000-1+3)
+	}
+}
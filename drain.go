@@ -0,0 +1,276 @@
+package minmaxheap
+
+import "sort"
+
+// DrainAll removes every element from h and returns them in ascending order.
+// The result is allocated once, with capacity h.Len().
+// The complexity is O(n log n) where n = h.Len().
+func DrainAll(h Interface) []interface{} {
+	xs := make([]interface{}, 0, h.Len())
+	for !IsEmpty(h) {
+		xs = append(xs, Pop(h))
+	}
+	return xs
+}
+
+// DrainAllMax removes every element from h and returns them in descending
+// order. The result is allocated once, with capacity h.Len().
+// The complexity is O(n log n) where n = h.Len().
+func DrainAllMax(h Interface) []interface{} {
+	xs := make([]interface{}, 0, h.Len())
+	for !IsEmpty(h) {
+		xs = append(xs, PopMax(h))
+	}
+	return xs
+}
+
+// SearchSorted returns the index of the first element of sorted that is not
+// less than x, or len(sorted) if there is none. sorted must be in ascending
+// order according to less, as returned by DrainAll.
+// The complexity is O(log n) where n = len(sorted).
+func SearchSorted(sorted []interface{}, x interface{}, less func(a, b interface{}) bool) int {
+	return sort.Search(len(sorted), func(i int) bool {
+		return !less(sorted[i], x)
+	})
+}
+
+// CanonicalSlice returns the elements of h in ascending order, breaking ties
+// between elements that are neither Less than the other with tieBreak. As long
+// as tieBreak orders all such ties, the result depends only on the elements in
+// h, not on how they are arranged, which makes it suitable for snapshots.
+//
+// CanonicalSlice sorts a permutation of the indices of h, so tieBreak is
+// always called with indices into h as it was. The elements are then read from
+// a clone if h implements Cloner, and otherwise from h itself, which is left
+// with the same arrangement.
+// The complexity is O(n log n) where n = h.Len().
+func CanonicalSlice(h Interface, tieBreak func(i, j int) bool) []interface{} {
+	c := Clone(h)
+	if c == nil {
+		c = h
+	}
+	perm := make([]int, c.Len())
+	for i := range perm {
+		perm[i] = i
+	}
+	sort.Sort(canonical{c, perm, tieBreak})
+	xs := make([]interface{}, len(perm))
+	for k, i := range perm {
+		xs[k] = element(c, i)
+	}
+	return xs
+}
+
+// canonical sorts perm, a permutation of the indices of h, by the order of
+// the elements of h with ties broken by tieBreak.
+type canonical struct {
+	h        Interface
+	perm     []int
+	tieBreak func(i, j int) bool
+}
+
+func (c canonical) Len() int      { return len(c.perm) }
+func (c canonical) Swap(i, j int) { c.perm[i], c.perm[j] = c.perm[j], c.perm[i] }
+
+func (c canonical) Less(i, j int) bool {
+	a, b := c.perm[i], c.perm[j]
+	if c.h.Less(a, b) {
+		return true
+	}
+	return !c.h.Less(b, a) && c.tieBreak(a, b)
+}
+
+// DrainZigzag removes elements from h alternately from the min and max ends,
+// starting with the minimum, and passes each to yield until h is empty or
+// yield returns false. If yield stops early, h remains a valid heap holding
+// the elements not yet yielded.
+//
+// yield has the shape of an iterator's yield function, so DrainZigzag can be
+// adapted to a range-over-func iterator by the caller.
+func DrainZigzag(h Interface, yield func(x interface{}) bool) {
+	for max := false; !IsEmpty(h); max = !max {
+		var x interface{}
+		if max {
+			x = PopMax(h)
+		} else {
+			x = Pop(h)
+		}
+		if !yield(x) {
+			return
+		}
+	}
+}
+
+// TrimEnds removes the k smallest and the k largest elements from h, leaving
+// the rest as a valid heap. smallest is in ascending and largest in descending
+// order. The smallest elements are removed first, so if 2k >= h.Len() the heap
+// ends up empty and largest holds whatever remained after taking smallest.
+// The complexity is O(k log n) where n = h.Len().
+func TrimEnds(h Interface, k int) (smallest, largest []interface{}) {
+	smallest = PopN(h, k)
+	largest = PopMaxN(h, k)
+	return smallest, largest
+}
+
+// PopN removes and returns up to k of the smallest elements of h, in
+// ascending order, leaving the rest as a valid heap. If k >= h.Len() every
+// element is returned; if k <= 0 none are and the result is nil.
+// The complexity is O(k log n) where n = h.Len().
+func PopN(h Interface, k int) []interface{} {
+	return popK(h, k)
+}
+
+// PopMaxN removes and returns up to k of the largest elements of h, in
+// descending order, leaving the rest as a valid heap. If k >= h.Len() every
+// element is returned; if k <= 0 none are and the result is nil.
+// The complexity is O(k log n) where n = h.Len().
+func PopMaxN(h Interface, k int) []interface{} {
+	if n := h.Len(); k > n {
+		k = n
+	}
+	if k <= 0 {
+		return nil
+	}
+	xs := make([]interface{}, 0, k)
+	for len(xs) < k {
+		xs = append(xs, PopMax(h))
+	}
+	return xs
+}
+
+// PopMinLimit removes and returns at most n of the smallest elements of h, in
+// ascending order, leaving the rest as a valid heap. It is meant for draining
+// a bounded batch per tick; if n >= h.Len() every element is returned. It is
+// equivalent to PopN.
+// The complexity is O(n log m) where m = h.Len().
+func PopMinLimit(h Interface, n int) []interface{} {
+	return PopN(h, n)
+}
+
+// PopMaxLimit is like PopMinLimit, but removes at most n of the largest
+// elements, in descending order. It is equivalent to PopMaxN.
+// The complexity is O(n log m) where m = h.Len().
+func PopMaxLimit(h Interface, n int) []interface{} {
+	return PopMaxN(h, n)
+}
+
+// Sorted returns the elements of h in ascending order without removing them.
+//
+// If h implements Cloner, Sorted drains a clone and leaves h untouched.
+// Otherwise it pops every element from h itself and then pushes them all back
+// and re-establishes the heap invariants. Afterwards h holds the same
+// elements, but possibly in a different arrangement, so indexes into h are not
+// preserved. h must not be used concurrently while Sorted runs.
+// The complexity is O(n log n) where n = h.Len().
+func Sorted(h Interface) []interface{} {
+	return sortedCopy(h)
+}
+
+// SortedDesc is like Sorted, but returns the elements in descending order.
+// The complexity is O(n log n) where n = h.Len().
+func SortedDesc(h Interface) []interface{} {
+	if c := Clone(h); c != nil {
+		return DrainAllMax(c)
+	}
+	xs := DrainAllMax(h)
+	for _, x := range xs {
+		h.Push(x)
+	}
+	Init(h)
+	return xs
+}
+
+// sortedCopy returns the elements of h in ascending order, draining a clone if
+// h implements Cloner and otherwise leaving h with the same elements as
+// before, though possibly in a different arrangement.
+func sortedCopy(h Interface) []interface{} {
+	if c := Clone(h); c != nil {
+		return DrainAll(c)
+	}
+	xs := DrainAll(h)
+	for _, x := range xs {
+		h.Push(x)
+	}
+	Init(h)
+	return xs
+}
+
+// PopMinGroup removes and returns the minimum element of h together with every
+// following minimum that eq reports equal to it, in ascending order. It stops
+// at the first minimum that differs, leaving it in h. It returns nil if h is
+// empty.
+// The complexity is O(k log n) where k is the size of the group.
+func PopMinGroup(h Interface, eq func(a, b interface{}) bool) []interface{} {
+	if IsEmpty(h) {
+		return nil
+	}
+	first := Pop(h)
+	group := []interface{}{first}
+	for !IsEmpty(h) && eq(first, element(h, 0)) {
+		group = append(group, Pop(h))
+	}
+	return group
+}
+
+// PopMaxGroup is like PopMinGroup, but takes the maximum and the following
+// maxima equal to it, in descending order.
+// The complexity is O(k log n) where k is the size of the group.
+func PopMaxGroup(h Interface, eq func(a, b interface{}) bool) []interface{} {
+	if IsEmpty(h) {
+		return nil
+	}
+	first := PopMax(h)
+	group := []interface{}{first}
+	for !IsEmpty(h) && eq(first, element(h, maxIndex(h))) {
+		group = append(group, PopMax(h))
+	}
+	return group
+}
+
+// DrainFunc removes elements from h in ascending order and passes each to fn
+// until h is empty or fn returns false. The element for which fn returned
+// false has already been removed; the rest remain as a valid heap.
+// The complexity is O(k log n) where k is the number of elements removed.
+func DrainFunc(h Interface, fn func(x interface{}) bool) {
+	for !IsEmpty(h) {
+		if !fn(Pop(h)) {
+			return
+		}
+	}
+}
+
+// DrainMaxFunc is like DrainFunc, but removes elements in descending order.
+// The complexity is O(k log n) where k is the number of elements removed.
+func DrainMaxFunc(h Interface, fn func(x interface{}) bool) {
+	for !IsEmpty(h) {
+		if !fn(PopMax(h)) {
+			return
+		}
+	}
+}
+
+// PopWhile removes and returns the minimum elements of h for which pred
+// returns true, in ascending order. It stops at the first minimum for which
+// pred returns false, leaving it in h, so only the removed elements and that
+// one are examined. It returns nil if no element is removed. Each minimum is
+// read with PeekMin before it is removed, which costs an extra Pop and Push
+// unless h implements Indexer.
+// The complexity is O(k log n) where k is the number of elements removed.
+func PopWhile(h Interface, pred func(x interface{}) bool) []interface{} {
+	var xs []interface{}
+	for !IsEmpty(h) && pred(PeekMin(h)) {
+		xs = append(xs, Pop(h))
+	}
+	return xs
+}
+
+// PopMaxWhile is like PopWhile, but removes the maximum elements, returning
+// them in descending order.
+// The complexity is O(k log n) where k is the number of elements removed.
+func PopMaxWhile(h Interface, pred func(x interface{}) bool) []interface{} {
+	var xs []interface{}
+	for !IsEmpty(h) && pred(PeekMax(h)) {
+		xs = append(xs, PopMax(h))
+	}
+	return xs
+}
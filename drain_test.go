@@ -0,0 +1,532 @@
+package minmaxheap
+
+import (
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestDrainAll(t *testing.T) {
+	rng := newTestRand(t)
+
+	h := new(myHeap)
+	for i := 0; i < 100; i++ {
+		Push(h, rng.Intn(50))
+	}
+
+	xs := DrainAll(h)
+	if h.Len() != 0 {
+		t.Fatalf("Len() = %d after DrainAll; want 0", h.Len())
+	}
+	if len(xs) != 100 {
+		t.Fatalf("len(DrainAll) = %d; want 100", len(xs))
+	}
+	for i := 1; i < len(xs); i++ {
+		if xs[i-1].(int) > xs[i].(int) {
+			t.Fatalf("DrainAll not ascending at %d: %v", i, xs)
+		}
+	}
+}
+
+func TestDrainAllMax(t *testing.T) {
+	rng := newTestRand(t)
+
+	h := new(myHeap)
+	for i := 0; i < 100; i++ {
+		Push(h, rng.Intn(50))
+	}
+
+	xs := DrainAllMax(h)
+	if h.Len() != 0 {
+		t.Fatalf("Len() = %d after DrainAllMax; want 0", h.Len())
+	}
+	if len(xs) != 100 {
+		t.Fatalf("len(DrainAllMax) = %d; want 100", len(xs))
+	}
+	for i := 1; i < len(xs); i++ {
+		if xs[i-1].(int) < xs[i].(int) {
+			t.Fatalf("DrainAllMax not descending at %d: %v", i, xs)
+		}
+	}
+}
+
+func TestDrainAllAllocs(t *testing.T) {
+	// values below 256 are boxed without allocating, so the only allocation
+	// left is the result slice.
+	const n = 100
+	h := make(myHeap, 0, n)
+	allocs := testing.AllocsPerRun(10, func() {
+		for i := 0; i < n; i++ {
+			h = append(h, i)
+		}
+		Init(&h)
+		DrainAll(&h)
+	})
+	if allocs != 1 {
+		t.Errorf("DrainAll allocated %v times; want 1", allocs)
+	}
+}
+
+func TestSearchSorted(t *testing.T) {
+	less := func(a, b interface{}) bool { return a.(int) < b.(int) }
+	sorted := []interface{}{1, 3, 3, 5}
+	for _, tc := range []struct{ x, want int }{
+		{0, 0}, {1, 0}, {2, 1}, {3, 1}, {4, 3}, {5, 3}, {6, 4},
+	} {
+		if got := SearchSorted(sorted, tc.x, less); got != tc.want {
+			t.Errorf("SearchSorted(%d) = %d; want %d", tc.x, got, tc.want)
+		}
+	}
+}
+
+type keyed struct{ key, id int }
+
+type keyedHeap []keyed
+
+func (h keyedHeap) Len() int           { return len(h) }
+func (h keyedHeap) Less(i, j int) bool { return h[i].key < h[j].key }
+func (h keyedHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *keyedHeap) Push(x interface{}) {
+	*h = append(*h, x.(keyed))
+}
+
+func (h *keyedHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+func TestCanonicalSlice(t *testing.T) {
+	rng := newTestRand(t)
+
+	var elems []keyed
+	for id := 0; id < 100; id++ {
+		elems = append(elems, keyed{key: rng.Intn(5), id: id})
+	}
+
+	var want []interface{}
+	for run := 0; run < 10; run++ {
+		rng.Shuffle(len(elems), func(i, j int) { elems[i], elems[j] = elems[j], elems[i] })
+		h := new(keyedHeap)
+		for _, e := range elems {
+			Push(h, e)
+		}
+
+		before := append(keyedHeap(nil), *h...)
+		got := CanonicalSlice(h, func(i, j int) bool { return (*h)[i].id < (*h)[j].id })
+		if !slices.Equal(*h, before) {
+			t.Fatalf("CanonicalSlice rearranged h")
+		}
+		if run == 0 {
+			want = got
+			continue
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("run %d: CanonicalSlice[%d] = %v; want %v", run, i, got[i], want[i])
+			}
+		}
+	}
+
+	for i := 1; i < len(want); i++ {
+		a, b := want[i-1].(keyed), want[i].(keyed)
+		if a.key > b.key || (a.key == b.key && a.id > b.id) {
+			t.Fatalf("CanonicalSlice not ordered at %d: %v, %v", i, a, b)
+		}
+	}
+
+	// a Cloner is read through a clone
+	h := &myHeap{5, 1, 3, 9, 7}
+	Init(h)
+	before := append(myHeap(nil), *h...)
+	got := CanonicalSlice(h, func(i, j int) bool { return false })
+	if want := []interface{}{1, 3, 5, 7, 9}; !slices.Equal(got, want) || !slices.Equal(*h, before) {
+		t.Errorf("CanonicalSlice of a Cloner = %v, leaving %v; want %v, leaving %v", got, *h, want, before)
+	}
+}
+
+func TestDrainZigzag(t *testing.T) {
+	rng := newTestRand(t)
+
+	for n := 0; n < 20; n++ {
+		h := new(myHeap)
+		for i := 0; i < n; i++ {
+			Push(h, rng.Intn(10))
+		}
+		sorted := append([]int(nil), *h...)
+		sort.Ints(sorted)
+
+		var got []int
+		DrainZigzag(h, func(x interface{}) bool {
+			got = append(got, x.(int))
+			return true
+		})
+		if len(got) != n {
+			t.Fatalf("n=%d: DrainZigzag yielded %d elements", n, len(got))
+		}
+		lo, hi := 0, n-1
+		for i, x := range got {
+			want := sorted[lo]
+			if i%2 == 0 {
+				lo++
+			} else {
+				want = sorted[hi]
+				hi--
+			}
+			if x != want {
+				t.Fatalf("n=%d: DrainZigzag[%d] = %d; want %d (got %v)", n, i, x, want, got)
+			}
+		}
+	}
+}
+
+func TestDrainZigzagStop(t *testing.T) {
+	h := new(myHeap)
+	for i := 0; i < 10; i++ {
+		Push(h, i)
+	}
+
+	var got []int
+	DrainZigzag(h, func(x interface{}) bool {
+		got = append(got, x.(int))
+		return len(got) < 3
+	})
+	if len(got) != 3 || got[0] != 0 || got[1] != 9 || got[2] != 1 {
+		t.Fatalf("DrainZigzag yielded %v; want [0 9 1]", got)
+	}
+	if h.Len() != 7 {
+		t.Fatalf("Len() = %d after stopping; want 7", h.Len())
+	}
+	h.verify(t, 0)
+}
+
+func TestTrimEnds(t *testing.T) {
+	rng := newTestRand(t)
+
+	const n, k = 101, 10
+	h := new(myHeap)
+	for i := 0; i < n; i++ {
+		Push(h, rng.Intn(1000))
+	}
+	sorted := append([]int(nil), *h...)
+	sort.Ints(sorted)
+
+	smallest, largest := TrimEnds(h, k)
+	if len(smallest) != k || len(largest) != k || h.Len() != n-2*k {
+		t.Fatalf("TrimEnds sizes = %d, %d, rest %d", len(smallest), len(largest), h.Len())
+	}
+	for i := 0; i < k; i++ {
+		if smallest[i].(int) != sorted[i] {
+			t.Errorf("smallest[%d] = %d; want %d", i, smallest[i], sorted[i])
+		}
+		if largest[i].(int) != sorted[n-1-i] {
+			t.Errorf("largest[%d] = %d; want %d", i, largest[i], sorted[n-1-i])
+		}
+	}
+	h.verify(t, 0)
+
+	sum, wantSum := 0, 0
+	for _, x := range *h {
+		sum += x
+	}
+	for _, x := range sorted[k : n-k] {
+		wantSum += x
+	}
+	if sum != wantSum {
+		t.Errorf("trimmed sum = %d; want %d", sum, wantSum)
+	}
+}
+
+func TestTrimEndsOverlap(t *testing.T) {
+	h := &myHeap{3, 1, 4, 1, 5}
+	Init(h)
+
+	smallest, largest := TrimEnds(h, 3)
+	if len(smallest) != 3 || len(largest) != 2 || h.Len() != 0 {
+		t.Fatalf("TrimEnds(3) of 5 = %v, %v, rest %v", smallest, largest, *h)
+	}
+	if largest[0].(int) != 5 || largest[1].(int) != 4 {
+		t.Errorf("largest = %v; want [5 4]", largest)
+	}
+}
+
+func TestPopMinMaxLimit(t *testing.T) {
+	rng := newTestRand(t)
+
+	h := new(myHeap)
+	for i := 0; i < 50; i++ {
+		Push(h, rng.Intn(100))
+	}
+	sorted := append([]int(nil), *h...)
+	sort.Ints(sorted)
+
+	mins := PopMinLimit(h, 10)
+	h.verify(t, 0)
+	maxs := PopMaxLimit(h, 10)
+	h.verify(t, 0)
+	if len(mins) != 10 || len(maxs) != 10 || h.Len() != 30 {
+		t.Fatalf("sizes = %d, %d, rest %d", len(mins), len(maxs), h.Len())
+	}
+	for i := 0; i < 10; i++ {
+		if mins[i].(int) != sorted[i] {
+			t.Errorf("PopMinLimit[%d] = %d; want %d", i, mins[i], sorted[i])
+		}
+		if maxs[i].(int) != sorted[49-i] {
+			t.Errorf("PopMaxLimit[%d] = %d; want %d", i, maxs[i], sorted[49-i])
+		}
+	}
+
+	// the rest still drains in order
+	rest := DrainAll(h)
+	for i, x := range rest {
+		if x.(int) != sorted[10+i] {
+			t.Fatalf("remaining[%d] = %d; want %d", i, x, sorted[10+i])
+		}
+	}
+
+	if xs := PopMinLimit(h, 0); len(xs) != 0 {
+		t.Errorf("PopMinLimit(empty, 0) = %v", xs)
+	}
+	h = &myHeap{2, 1, 3}
+	Init(h)
+	if xs := PopMaxLimit(h, 10); len(xs) != 3 || h.Len() != 0 {
+		t.Errorf("PopMaxLimit(10) of 3 = %v, rest %v", xs, *h)
+	}
+	if xs := PopMinLimit(&myHeap{1}, -1); len(xs) != 0 {
+		t.Errorf("PopMinLimit(-1) = %v", xs)
+	}
+}
+
+func TestPopN(t *testing.T) {
+	for _, tc := range []struct {
+		heap []int
+		k    int
+		min  []int
+		max  []int
+	}{
+		{nil, 3, nil, nil},
+		{[]int{4, 1, 3}, 0, nil, nil},
+		{[]int{4, 1, 3}, -2, nil, nil},
+		{[]int{4, 1, 3, 2}, 2, []int{1, 2}, []int{4, 3}},
+		{[]int{4, 1, 3}, 3, []int{1, 3, 4}, []int{4, 3, 1}},
+		{[]int{4, 1, 3}, 10, []int{1, 3, 4}, []int{4, 3, 1}},
+	} {
+		for _, max := range []bool{false, true} {
+			h := append(myHeap(nil), tc.heap...)
+			Init(&h)
+			var xs []interface{}
+			want := tc.min
+			if max {
+				xs = PopMaxN(&h, tc.k)
+				want = tc.max
+			} else {
+				xs = PopN(&h, tc.k)
+			}
+			if len(xs) != len(want) {
+				t.Fatalf("%v k=%d max=%v: got %v; want %v", tc.heap, tc.k, max, xs, want)
+			}
+			for i, x := range xs {
+				if x.(int) != want[i] {
+					t.Fatalf("%v k=%d max=%v: got %v; want %v", tc.heap, tc.k, max, xs, want)
+				}
+			}
+			if h.Len() != len(tc.heap)-len(want) {
+				t.Fatalf("%v k=%d max=%v: Len() = %d after popping %d", tc.heap, tc.k, max, h.Len(), len(want))
+			}
+			h.verify(t, 0)
+		}
+	}
+}
+
+func TestPopMinMaxGroup(t *testing.T) {
+	rng := newTestRand(t)
+
+	// priorities 0 through 4, each appearing priority+1 times
+	h := new(keyedHeap)
+	id := 0
+	for key := 0; key < 5; key++ {
+		for i := 0; i <= key; i++ {
+			*h = append(*h, keyed{key: key, id: id})
+			id++
+		}
+	}
+	rng.Shuffle(h.Len(), h.Swap)
+	Init(h)
+
+	sameKey := func(a, b interface{}) bool { return a.(keyed).key == b.(keyed).key }
+
+	for key := 0; key < 3; key++ {
+		group := PopMinGroup(h, sameKey)
+		if len(group) != key+1 {
+			t.Fatalf("PopMinGroup %d returned %d elements; want %d", key, len(group), key+1)
+		}
+		for _, x := range group {
+			if x.(keyed).key != key {
+				t.Fatalf("PopMinGroup %d returned %v", key, group)
+			}
+		}
+	}
+
+	group := PopMaxGroup(h, sameKey)
+	if len(group) != 5 || group[0].(keyed).key != 4 {
+		t.Fatalf("PopMaxGroup returned %v; want five elements with key 4", group)
+	}
+	group = PopMaxGroup(h, sameKey)
+	if len(group) != 4 || group[0].(keyed).key != 3 {
+		t.Fatalf("PopMaxGroup returned %v; want four elements with key 3", group)
+	}
+	if h.Len() != 0 {
+		t.Fatalf("Len() = %d; want 0", h.Len())
+	}
+	if group := PopMinGroup(h, sameKey); group != nil {
+		t.Fatalf("PopMinGroup(empty) = %v", group)
+	}
+}
+
+func TestDrainFunc(t *testing.T) {
+	h := new(myHeap)
+	for i := 0; i < 20; i++ {
+		Push(h, (i*7)%20)
+	}
+
+	var got []int
+	DrainFunc(h, func(x interface{}) bool {
+		got = append(got, x.(int))
+		return x.(int) < 4
+	})
+	if len(got) != 5 || got[4] != 4 {
+		t.Fatalf("DrainFunc yielded %v; want [0 1 2 3 4]", got)
+	}
+	h.verify(t, 0)
+
+	got = got[:0]
+	DrainMaxFunc(h, func(x interface{}) bool {
+		got = append(got, x.(int))
+		return x.(int) > 17
+	})
+	if len(got) != 3 || got[0] != 19 || got[2] != 17 {
+		t.Fatalf("DrainMaxFunc yielded %v; want [19 18 17]", got)
+	}
+	h.verify(t, 0)
+
+	// the rest is still in order
+	for want := 5; h.Len() > 0; want++ {
+		if x := Pop(h).(int); x != want {
+			t.Fatalf("Pop = %d; want %d", x, want)
+		}
+	}
+
+	calls := 0
+	DrainFunc(h, func(interface{}) bool { calls++; return true })
+	if calls != 0 {
+		t.Errorf("DrainFunc on an empty heap called fn %d times", calls)
+	}
+}
+
+func TestSorted(t *testing.T) {
+	rng := newTestRand(t)
+
+	h := new(myHeap)
+	var want []int
+	for i := 0; i < 100; i++ {
+		x := rng.Intn(50)
+		Push(h, x)
+		want = append(want, x)
+	}
+	sort.Ints(want)
+
+	asc := Sorted(h)
+	desc := SortedDesc(h)
+	if h.Len() != len(want) {
+		t.Fatalf("Len() = %d after Sorted; want %d", h.Len(), len(want))
+	}
+	h.verify(t, 0)
+	for i, x := range want {
+		if asc[i].(int) != x {
+			t.Fatalf("Sorted()[%d] = %v; want %d", i, asc[i], x)
+		}
+		if desc[len(want)-1-i].(int) != x {
+			t.Fatalf("SortedDesc()[%d] = %v; want %d", len(want)-1-i, desc[len(want)-1-i], x)
+		}
+	}
+	before := append(myHeap(nil), *h...)
+	Sorted(h)
+	if !slices.Equal(*h, before) {
+		t.Errorf("Sorted rearranged a Cloner: %v; want %v", *h, before)
+	}
+
+	// without Cloner, Sorted falls back to draining and rebuilding h
+	plain := struct{ Interface }{h}
+	asc = Sorted(plain)
+	desc = SortedDesc(plain)
+	h.verify(t, 0)
+	for i, x := range want {
+		if asc[i].(int) != x || desc[len(want)-1-i].(int) != x {
+			t.Fatalf("Sorted or SortedDesc without Cloner differs at %d", i)
+		}
+	}
+	if xs := Sorted(new(myHeap)); len(xs) != 0 {
+		t.Errorf("Sorted(empty) = %v; want empty", xs)
+	}
+}
+
+func TestPopWhile(t *testing.T) {
+	rng := newTestRand(t)
+
+	h := new(myHeap)
+	var sorted []int
+	for i := 0; i < 100; i++ {
+		x := rng.Intn(100)
+		Push(h, x)
+		sorted = append(sorted, x)
+	}
+	sort.Ints(sorted)
+
+	calls := 0
+	below := func(t int) func(x interface{}) bool {
+		return func(x interface{}) bool { calls++; return x.(int) < t }
+	}
+	above := func(t int) func(x interface{}) bool {
+		return func(x interface{}) bool { calls++; return x.(int) > t }
+	}
+
+	lo := PopWhile(h, below(30))
+	n := sort.SearchInts(sorted, 30)
+	if len(lo) != n || calls != n+1 {
+		t.Fatalf("PopWhile(< 30) removed %d with %d calls; want %d with %d", len(lo), calls, n, n+1)
+	}
+	for i, x := range lo {
+		if x.(int) != sorted[i] {
+			t.Fatalf("PopWhile(< 30)[%d] = %v; want %d", i, x, sorted[i])
+		}
+	}
+	h.verify(t, 0)
+
+	hi := PopMaxWhile(h, above(70))
+	m := len(sorted) - sort.SearchInts(sorted, 71)
+	if len(hi) != m {
+		t.Fatalf("PopMaxWhile(> 70) removed %d; want %d", len(hi), m)
+	}
+	for i, x := range hi {
+		if x.(int) != sorted[len(sorted)-1-i] {
+			t.Fatalf("PopMaxWhile(> 70)[%d] = %v; want %d", i, x, sorted[len(sorted)-1-i])
+		}
+	}
+	if h.Len() != len(sorted)-n-m {
+		t.Fatalf("Len() = %d; want %d", h.Len(), len(sorted)-n-m)
+	}
+	h.verify(t, 0)
+
+	if xs := PopWhile(h, below(-1)); xs != nil {
+		t.Errorf("PopWhile(< -1) = %v; want nil", xs)
+	}
+	if xs := PopMaxWhile(h, above(-1)); h.Len() != 0 || len(xs) != len(sorted)-n-m {
+		t.Errorf("PopMaxWhile(> -1) removed %d, left %d", len(xs), h.Len())
+	}
+	if xs := PopWhile(h, below(1000)); xs != nil {
+		t.Errorf("PopWhile of an empty heap = %v; want nil", xs)
+	}
+}
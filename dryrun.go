@@ -0,0 +1,66 @@
+package minmaxheap
+
+// FixPath returns the indices whose elements Fix(h, i) would move if the
+// element at i were changed to some new value, in the order they would first
+// be moved, without modifying h. compare(j) describes the new value: it must
+// return a negative number if the new value is less than the element at
+// index j, zero if they are equal and a positive number if it is greater.
+// Indices passed to compare refer to positions in h as it is now.
+// The complexity is O(log n) where n = h.Len().
+func FixPath(h Interface, i int, compare func(j int) int) []int {
+	checkIndex("FixPath", h, i)
+	d := &dryRun{
+		h:       h,
+		compare: compare,
+		moved:   map[int]int{i: -1},
+		seen:    map[int]bool{},
+	}
+	up(d, i)
+	down(d, i, h.Len())
+	return d.path
+}
+
+// dryRun runs the sift routines against a heap without modifying it. It tracks
+// where elements would have moved in moved, which maps a position to the
+// index in h of the element that would be there, or -1 for the new value.
+type dryRun struct {
+	h       Interface
+	compare func(j int) int
+	moved   map[int]int
+	seen    map[int]bool
+	path    []int
+}
+
+func (d *dryRun) at(i int) int {
+	if j, ok := d.moved[i]; ok {
+		return j
+	}
+	return i
+}
+
+func (d *dryRun) Len() int { return d.h.Len() }
+
+func (d *dryRun) Less(i, j int) bool {
+	i, j = d.at(i), d.at(j)
+	switch {
+	case i == -1:
+		return d.compare(j) < 0
+	case j == -1:
+		return d.compare(i) > 0
+	default:
+		return d.h.Less(i, j)
+	}
+}
+
+func (d *dryRun) Swap(i, j int) {
+	d.moved[i], d.moved[j] = d.at(j), d.at(i)
+	for _, k := range []int{i, j} {
+		if !d.seen[k] {
+			d.seen[k] = true
+			d.path = append(d.path, k)
+		}
+	}
+}
+
+func (d *dryRun) Push(x interface{}) { panic("minmaxheap: dry run does not push") }
+func (d *dryRun) Pop() interface{}   { panic("minmaxheap: dry run does not pop") }
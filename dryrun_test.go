@@ -0,0 +1,58 @@
+package minmaxheap
+
+import "testing"
+
+// swapRecorder records the indices moved by Swap, in the order they are first
+// moved.
+type swapRecorder struct {
+	*myHeap
+	seen map[int]bool
+	path []int
+}
+
+func (r *swapRecorder) Swap(i, j int) {
+	r.myHeap.Swap(i, j)
+	for _, k := range []int{i, j} {
+		if !r.seen[k] {
+			r.seen[k] = true
+			r.path = append(r.path, k)
+		}
+	}
+}
+
+func TestFixPath(t *testing.T) {
+	rng := newTestRand(t)
+
+	for trial := 0; trial < 1000; trial++ {
+		n := 1 + rng.Intn(100)
+		h := new(myHeap)
+		for i := 0; i < n; i++ {
+			Push(h, rng.Intn(50))
+		}
+		before := append(myHeap(nil), *h...)
+
+		i := rng.Intn(n)
+		v := rng.Intn(60) - 5
+		got := FixPath(h, i, func(j int) int { return v - (*h)[j] })
+
+		for k := range before {
+			if (*h)[k] != before[k] {
+				t.Fatalf("FixPath modified the heap at [%d]", k)
+			}
+		}
+
+		r := &swapRecorder{myHeap: h, seen: map[int]bool{}}
+		(*h)[i] = v
+		Fix(r, i)
+		h.verify(t, 0)
+
+		if len(got) != len(r.path) {
+			t.Fatalf("FixPath(%v, %d, %d) = %v; Fix moved %v", before, i, v, got, r.path)
+		}
+		for k := range got {
+			if got[k] != r.path[k] {
+				t.Fatalf("FixPath(%v, %d, %d) = %v; Fix moved %v", before, i, v, got, r.path)
+			}
+		}
+	}
+}
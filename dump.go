@@ -0,0 +1,46 @@
+package minmaxheap
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dump returns a one-line representation of the backing array of h, in index
+// order, such as "{6 10 13 3}". valueString formats the element at index i.
+// For heaps of ints the result can be read back with ParseInts.
+func Dump(h Interface, valueString func(i int) string) string {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, n := 0, h.Len(); i < n; i++ {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(valueString(i))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// ParseInts parses a backing array written by Dump, such as "{6 10 13 3}".
+// Elements may also be separated by commas, so Go composite literals can be
+// pasted as is. The elements are returned in order; load them into a heap
+// without calling Init to reproduce the dumped layout exactly.
+func ParseInts(s string) ([]int, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "{") || !strings.HasSuffix(s, "}") {
+		return nil, fmt.Errorf("minmaxheap: %q is not enclosed in braces", s)
+	}
+	fields := strings.FieldsFunc(s[1:len(s)-1], func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+	xs := make([]int, 0, len(fields))
+	for _, f := range fields {
+		x, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("minmaxheap: %w", err)
+		}
+		xs = append(xs, x)
+	}
+	return xs, nil
+}
@@ -0,0 +1,49 @@
+package minmaxheap
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestDumpParseInts(t *testing.T) {
+	rng := newTestRand(t)
+
+	for n := 0; n < 50; n++ {
+		h := new(myHeap)
+		for i := 0; i < n; i++ {
+			Push(h, rng.Intn(200)-100)
+		}
+
+		s := Dump(h, func(i int) string { return strconv.Itoa((*h)[i]) })
+		xs, err := ParseInts(s)
+		if err != nil {
+			t.Fatalf("ParseInts(%q): %v", s, err)
+		}
+		if len(xs) != h.Len() {
+			t.Fatalf("ParseInts(%q) has %d elements; want %d", s, len(xs), h.Len())
+		}
+		for i := range xs {
+			if xs[i] != (*h)[i] {
+				t.Fatalf("ParseInts(%q)[%d] = %d; want %d", s, i, xs[i], (*h)[i])
+			}
+		}
+		myHeap(xs).verify(t, 0)
+	}
+}
+
+func TestParseInts(t *testing.T) {
+	xs, err := ParseInts("{6, 10, 13, 3, 12}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := myHeap(xs)
+	if got := Dump(&h, func(i int) string { return strconv.Itoa(h[i]) }); got != "{6 10 13 3 12}" {
+		t.Errorf("Dump(ParseInts(...)) = %q", got)
+	}
+
+	for _, s := range []string{"", "6 10", "{6 x}", "{6"} {
+		if _, err := ParseInts(s); err == nil {
+			t.Errorf("ParseInts(%q) succeeded; want error", s)
+		}
+	}
+}
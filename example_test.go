@@ -2,8 +2,10 @@ package minmaxheap_test
 
 import (
 	"fmt"
+	"testing"
 
 	heap "storj.io/minmaxheap"
+	"storj.io/minmaxheap/minmaxheaptest"
 )
 
 // IntHeap is a min-heap of ints.
@@ -27,6 +29,20 @@ func (h *IntHeap) Pop() interface{} {
 	return x
 }
 
+// Reserve implements heap.Reserver, so InitReserve can preallocate.
+func (h *IntHeap) Reserve(n int) {
+	if n > cap(*h) {
+		grown := make(IntHeap, len(*h), n)
+		copy(grown, *h)
+		*h = grown
+	}
+}
+
+// Push and Pop have pointer receivers, so *IntHeap implements Interface but
+// IntHeap does not. Asserting it at package level reports a mistake at compile
+// time instead of at the first call site.
+var _ heap.Interface = (*IntHeap)(nil)
+
 func Example() {
 	h := &IntHeap{2, 1, 5}
 	heap.Init(h)
@@ -38,3 +54,67 @@ func Example() {
 	// min: 1
 	// max: 5
 }
+
+func ExampleInterface() {
+	// Pass a pointer: heap.Init(IntHeap{...}) does not compile, because the
+	// Push method has a pointer receiver.
+	h := &IntHeap{5, 2, 8}
+	heap.Init(h)
+
+	fmt.Println("min:", heap.Pop(h))
+	fmt.Println("len:", h.Len())
+	// Output:
+	// min: 2
+	// len: 2
+}
+
+func ExampleSearchSorted() {
+	h := &IntHeap{7, 3, 9, 1, 5}
+	heap.Init(h)
+
+	sorted := heap.DrainAll(h)
+	i := heap.SearchSorted(sorted, 4, func(a, b interface{}) bool {
+		return a.(int) < b.(int)
+	})
+	fmt.Println("at least 4:", sorted[i:])
+	// Output:
+	// at least 4: [5 7 9]
+}
+
+func ExampleInitReserve() {
+	h := &IntHeap{2, 1, 5}
+	heap.InitReserve(h, 100)
+	fmt.Println("len:", h.Len(), "cap:", cap(*h))
+
+	for i := 0; i < 97; i++ {
+		heap.Push(h, i)
+	}
+	fmt.Println("len:", h.Len(), "cap:", cap(*h))
+	// Output:
+	// len: 3 cap: 100
+	// len: 100 cap: 100
+}
+
+func TestExampleIntHeap(t *testing.T) {
+	h := &IntHeap{2, 1, 5}
+	heap.Init(h)
+	heap.Push(h, 3)
+
+	minmaxheaptest.AssertDrainsTo(t, h, []interface{}{1, 2, 3, 5})
+	minmaxheaptest.AssertDrainsToMax(t, h, []interface{}{5, 3, 2, 1})
+}
+
+func ExampleNewOrdered() {
+	h := heap.NewOrdered[int]()
+	for _, x := range []int{2, 1, 5, 3} {
+		h.Push(x)
+	}
+
+	fmt.Println("min:", h.PopMin())
+	fmt.Println("max:", h.PopMax())
+	fmt.Println("len:", h.Len())
+	// Output:
+	// min: 1
+	// max: 5
+	// len: 2
+}
@@ -0,0 +1,79 @@
+package minmaxheap_test
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"testing"
+
+	heap "storj.io/minmaxheap"
+	"storj.io/minmaxheap/minmaxheaptest"
+)
+
+// ints returns n pseudo-random ints in [0, 1000), varying with seed.
+func ints(n, seed int) []int {
+	xs := make([]int, n)
+	for i := range xs {
+		xs[i] = (i*7919 + seed*104729) % 1000
+	}
+	return xs
+}
+
+// sortedOf returns the elements of heaps in ascending order.
+func sortedOf(heaps ...IntHeap) []interface{} {
+	var all []int
+	for _, h := range heaps {
+		all = append(all, h...)
+	}
+	sort.Ints(all)
+	xs := make([]interface{}, len(all))
+	for i, x := range all {
+		xs[i] = x
+	}
+	return xs
+}
+
+func TestPushSlice(t *testing.T) {
+	for _, size := range [][2]int{{0, 0}, {0, 30}, {30, 0}, {1, 1}, {100, 3}, {100, 50}, {3, 100}, {1000, 200}} {
+		t.Run(fmt.Sprint(size), func(t *testing.T) {
+			h := IntHeap(ints(size[0], 1))
+			heap.Init(&h)
+			batch := IntHeap(ints(size[1], 2))
+			want := sortedOf(h, batch)
+
+			xs := make([]interface{}, len(batch))
+			for i, x := range batch {
+				xs[i] = x
+			}
+			heap.PushSlice(&h, xs)
+			if err := heap.Verify(&h); err != nil {
+				t.Fatal(err)
+			}
+			minmaxheaptest.AssertDrainsTo(t, &h, want)
+		})
+	}
+}
+
+// TestMergeNonCloner covers the path where Merge has to read src through Pop
+// and Push, since IntHeap does not implement Cloner.
+func TestMergeNonCloner(t *testing.T) {
+	for _, size := range [][2]int{{0, 0}, {0, 20}, {20, 0}, {1, 1}, {50, 200}, {200, 50}} {
+		t.Run(fmt.Sprint(size), func(t *testing.T) {
+			dst := IntHeap(ints(size[0], 3))
+			src := IntHeap(ints(size[1], 4))
+			heap.Init(&dst)
+			heap.Init(&src)
+			before := append(IntHeap(nil), src...)
+			want := sortedOf(dst, src)
+
+			heap.Merge(&dst, &src)
+			if !slices.Equal(src, before) {
+				t.Fatalf("Merge changed src to %v; want %v", src, before)
+			}
+			if err := heap.Verify(&dst); err != nil {
+				t.Fatal(err)
+			}
+			minmaxheaptest.AssertDrainsTo(t, &dst, want)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package minmaxheap
+
+import "cmp"
+
+// Heap is a min-max heap of elements of type T, ordered by a less function.
+// Unlike the functions operating on Interface, its methods do not box
+// elements in interfaces, so pushing and popping does not allocate beyond
+// growing the backing slice.
+type Heap[T any] struct {
+	data heapData[T]
+}
+
+// New returns an empty heap ordered by less.
+func New[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{data: heapData[T]{less: less}}
+}
+
+// NewOrdered returns an empty heap ordered by the natural < ordering of T.
+func NewOrdered[T cmp.Ordered]() *Heap[T] {
+	return New(cmp.Less[T])
+}
+
+// Len returns the number of elements in the heap.
+func (h *Heap[T]) Len() int {
+	return len(h.data.items)
+}
+
+// IsEmpty reports whether the heap has no elements.
+func (h *Heap[T]) IsEmpty() bool {
+	return len(h.data.items) == 0
+}
+
+// Push pushes the element x onto the heap.
+// The complexity is O(log n) where n = h.Len().
+func (h *Heap[T]) Push(x T) {
+	h.data.items = append(h.data.items, x)
+	up(&h.data, len(h.data.items)-1)
+}
+
+// PopMin removes and returns the minimum element from the heap. It panics if
+// the heap is empty.
+// The complexity is O(log n) where n = h.Len().
+func (h *Heap[T]) PopMin() T {
+	checkNotEmpty("PopMin", len(h.data.items))
+	return h.removeAt(0)
+}
+
+// PopMax removes and returns the maximum element from the heap. It panics if
+// the heap is empty.
+// The complexity is O(log n) where n = h.Len().
+func (h *Heap[T]) PopMax() T {
+	checkNotEmpty("PopMax", len(h.data.items))
+	return h.removeAt(maxIndex(&h.data))
+}
+
+// PeekMin returns the minimum element without removing it. It panics if the
+// heap is empty.
+// The complexity is O(1).
+func (h *Heap[T]) PeekMin() T {
+	checkNotEmpty("PeekMin", len(h.data.items))
+	return h.data.items[0]
+}
+
+// PeekMax returns the maximum element without removing it. It panics if the
+// heap is empty.
+// The complexity is O(1).
+func (h *Heap[T]) PeekMax() T {
+	checkNotEmpty("PeekMax", len(h.data.items))
+	return h.data.items[maxIndex(&h.data)]
+}
+
+// Fix re-establishes the heap ordering after the element at index i has
+// changed its value, for example through a pointer when T is a pointer type.
+// The complexity is O(log n) where n = h.Len().
+func (h *Heap[T]) Fix(i int) {
+	Fix(&h.data, i)
+}
+
+// removeAt removes and returns the element at index i.
+func (h *Heap[T]) removeAt(i int) T {
+	removeToEnd(&h.data, i)
+	return h.data.pop()
+}
+
+// heapData adapts a slice and less function to Interface, so that the sift
+// routines can operate on it. The Heap methods do their own pushing and
+// popping to avoid boxing; Push and Pop exist only to satisfy Interface.
+type heapData[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func (d *heapData[T]) Len() int           { return len(d.items) }
+func (d *heapData[T]) Less(i, j int) bool { return d.less(d.items[i], d.items[j]) }
+func (d *heapData[T]) Swap(i, j int)      { d.items[i], d.items[j] = d.items[j], d.items[i] }
+
+func (d *heapData[T]) Push(x interface{}) {
+	d.items = append(d.items, x.(T))
+}
+
+func (d *heapData[T]) Pop() interface{} {
+	return d.pop()
+}
+
+// pop removes and returns the last item without boxing it.
+func (d *heapData[T]) pop() T {
+	n := len(d.items) - 1
+	x := d.items[n]
+	var zero T
+	d.items[n] = zero
+	d.items = d.items[:n]
+	return x
+}
@@ -0,0 +1,160 @@
+package minmaxheap
+
+import (
+	"sort"
+	"testing"
+)
+
+func (h *Heap[T]) verify(t *testing.T) {
+	t.Helper()
+	if vs := AllViolations(&h.data); len(vs) > 0 {
+		t.Fatalf("heap invariant violated: %v", vs)
+	}
+}
+
+func TestHeap(t *testing.T) {
+	rng := newTestRand(t)
+
+	const n = 1_000
+	h := NewOrdered[int]()
+	var want []int
+	for i := 0; i < n; i++ {
+		x := rng.Intn(n / 2)
+		h.Push(x)
+		want = append(want, x)
+		h.verify(t)
+	}
+	sort.Ints(want)
+
+	if h.Len() != n {
+		t.Fatalf("Len() = %d; want %d", h.Len(), n)
+	}
+	for lo, hi := 0, n-1; h.Len() > 0; {
+		if x := h.PeekMin(); x != want[lo] {
+			t.Fatalf("PeekMin = %d; want %d", x, want[lo])
+		}
+		if x := h.PeekMax(); x != want[hi] {
+			t.Fatalf("PeekMax = %d; want %d", x, want[hi])
+		}
+		if rng.Intn(2) == 0 {
+			if x := h.PopMin(); x != want[lo] {
+				t.Fatalf("PopMin = %d; want %d", x, want[lo])
+			}
+			lo++
+		} else {
+			if x := h.PopMax(); x != want[hi] {
+				t.Fatalf("PopMax = %d; want %d", x, want[hi])
+			}
+			hi--
+		}
+		h.verify(t)
+	}
+}
+
+func TestHeapFix(t *testing.T) {
+	type item struct{ priority int }
+	h := New(func(a, b *item) bool { return a.priority < b.priority })
+
+	items := make([]*item, 20)
+	for i := range items {
+		items[i] = &item{priority: i}
+		h.Push(items[i])
+	}
+
+	for i, it := range h.data.items {
+		if it == items[10] {
+			it.priority = -1
+			h.Fix(i)
+			break
+		}
+	}
+	h.verify(t)
+	if x := h.PopMin(); x != items[10] {
+		t.Fatalf("PopMin = %v; want the fixed item", x)
+	}
+
+	// popped slots are cleared, so the heap does not keep popped items alive
+	if backing := h.data.items[:h.Len()+1]; backing[h.Len()] != nil {
+		t.Error("PopMin left the popped item in the backing array")
+	}
+}
+
+func TestHeapEmpty(t *testing.T) {
+	h := NewOrdered[string]()
+	if !h.IsEmpty() {
+		t.Error("IsEmpty of a new heap = false; want true")
+	}
+	h.Push("a")
+	if h.IsEmpty() {
+		t.Error("IsEmpty after Push = true; want false")
+	}
+	h.PopMin()
+	for name, op := range map[string]func(){
+		"PopMin":  func() { h.PopMin() },
+		"PopMax":  func() { h.PopMax() },
+		"PeekMin": func() { h.PeekMin() },
+		"PeekMax": func() { h.PeekMax() },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s on an empty heap did not panic", name)
+				}
+			}()
+			op()
+		}()
+	}
+}
+
+func TestHeapAllocs(t *testing.T) {
+	h := NewOrdered[int]()
+	for i := 0; i < 1000; i++ {
+		h.Push(i)
+	}
+	for h.Len() > 0 {
+		h.PopMin()
+	}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		for i := 0; i < 1000; i++ {
+			h.Push(1000 + i)
+		}
+		for h.Len() > 0 {
+			h.PopMin()
+			if h.Len() > 0 {
+				h.PopMax()
+			}
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("Push and Pop allocated %v times; want 0", allocs)
+	}
+}
+
+func BenchmarkHeap(b *testing.B) {
+	const n = 10000
+	b.Run("generic", func(b *testing.B) {
+		h := NewOrdered[int]()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < n; j++ {
+				h.Push(j)
+			}
+			for h.Len() > 0 {
+				h.PopMin()
+			}
+		}
+	})
+	b.Run("interface", func(b *testing.B) {
+		h := make(myHeap, 0, n)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < n; j++ {
+				Push(&h, j)
+			}
+			for h.Len() > 0 {
+				Pop(&h)
+			}
+		}
+	})
+}
@@ -1,3 +1,3 @@
 module storj.io/minmaxheap
 
-go 1.16
+go 1.21
@@ -0,0 +1,35 @@
+package minmaxheap
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+)
+
+// GobEncode implements gob.GobEncoder. It encodes the elements of h in their
+// current order; T must itself be encodable by gob. The less function is not
+// encoded.
+func (h *Heap[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(h.data.items); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder. It replaces the elements of h with the
+// decoded ones and re-establishes the heap invariants with Init, so the data
+// need not have come from a valid heap. Since the less function is not
+// encoded, h must have been created by New or NewOrdered.
+func (h *Heap[T]) GobDecode(data []byte) error {
+	if h.data.less == nil {
+		return errors.New("minmaxheap: GobDecode into a Heap without a less function")
+	}
+	var items []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&items); err != nil {
+		return err
+	}
+	h.data.items = items
+	Init(&h.data)
+	return nil
+}
@@ -0,0 +1,56 @@
+package minmaxheap
+
+import (
+	"bytes"
+	"encoding/gob"
+	"slices"
+	"testing"
+)
+
+func TestHeapGob(t *testing.T) {
+	rng := newTestRand(t)
+
+	for _, n := range []int{0, 1, 2, 100} {
+		h := NewOrdered[int]()
+		for i := 0; i < n; i++ {
+			h.Push(rng.Intn(50))
+		}
+
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(h); err != nil {
+			t.Fatalf("n=%d: Encode: %v", n, err)
+		}
+		got := NewOrdered[int]()
+		got.Push(-1) // replaced by the decoded elements
+		if err := gob.NewDecoder(&buf).Decode(got); err != nil {
+			t.Fatalf("n=%d: Decode: %v", n, err)
+		}
+		got.verify(t)
+		if !slices.Equal(got.data.items, h.data.items) {
+			t.Fatalf("n=%d: decoded %v; want %v", n, got.data.items, h.data.items)
+		}
+	}
+}
+
+func TestHeapGobDecodeInit(t *testing.T) {
+	// the decoded elements need not be in heap order
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode([]int{5, 4, 3, 2, 1}); err != nil {
+		t.Fatal(err)
+	}
+	h := NewOrdered[int]()
+	if err := h.GobDecode(buf.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	h.verify(t)
+	if h.PeekMin() != 1 || h.PeekMax() != 5 {
+		t.Errorf("PeekMin, PeekMax = %d, %d; want 1, 5", h.PeekMin(), h.PeekMax())
+	}
+
+	if err := new(Heap[int]).GobDecode(buf.Bytes()); err == nil {
+		t.Error("GobDecode into a zero Heap succeeded")
+	}
+	if err := NewOrdered[int]().GobDecode([]byte("junk")); err == nil {
+		t.Error("GobDecode of junk succeeded")
+	}
+}
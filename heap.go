@@ -9,13 +9,132 @@ package minmaxheap
 
 import (
 	"container/heap"
+	"fmt"
 	"math/bits"
 )
 
 // Interface copied from the heap package, so code that imports minmaxheap does
 // not also have to import "container/heap".
+//
+// Push and Pop change the length of the heap, so implementations usually give
+// them pointer receivers. In that case it is the pointer type that implements
+// Interface, which can be checked at compile time with
+//
+//	var _ minmaxheap.Interface = (*MyHeap)(nil)
 type Interface = heap.Interface
 
+// Emptier is implemented by heaps that can report emptiness more cheaply than
+// by computing Len, for example a filtered view over a larger slice.
+type Emptier interface {
+	IsEmpty() bool
+}
+
+// IsEmpty reports whether h has no elements. It uses h's IsEmpty method when h
+// implements Emptier, and h.Len() == 0 otherwise.
+func IsEmpty(h Interface) bool {
+	if e, ok := h.(Emptier); ok {
+		return e.IsEmpty()
+	}
+	return h.Len() == 0
+}
+
+// Reserver is implemented by heaps that can preallocate room for future
+// elements. Reserve(n) should make room for at least n elements in total,
+// so that Push does not reallocate until Len exceeds n. It must not change
+// Len or the order of the elements.
+type Reserver interface {
+	Reserve(n int)
+}
+
+// Reserve lets h preallocate room for n elements in total if it implements
+// Reserver, and does nothing otherwise. It never changes h.Len() or the order
+// of the elements.
+func Reserve(h Interface, n int) {
+	if r, ok := h.(Reserver); ok {
+		r.Reserve(n)
+	}
+}
+
+// Resetter is implemented by heaps that can remove all their elements at
+// once, for example by truncating a backing slice to length zero while
+// keeping its capacity for reuse.
+type Resetter interface {
+	Reset()
+}
+
+// Clear removes all elements from h. It uses h's Reset method when h
+// implements Resetter, and otherwise pops the elements one at a time, which
+// needs no sifting since the heap ends up empty.
+// The complexity is O(n) where n = h.Len(), or that of Reset.
+func Clear(h Interface) {
+	if r, ok := h.(Resetter); ok {
+		r.Reset()
+		return
+	}
+	for n := h.Len(); n > 0; n-- {
+		h.Pop()
+	}
+}
+
+// Indexer is implemented by heaps that can return the element at an index
+// directly. The functions that read elements without removing them, such as
+// PeekMin and PeekMax, use At when it is available. Otherwise, since Interface
+// offers no direct access to elements, they move the element to the end, call
+// Pop, call Push with the same element and move it back, so h must tolerate
+// those calls. At must not change h.
+type Indexer interface {
+	At(i int) interface{}
+}
+
+// Cloner is implemented by heaps that can copy themselves. Clone must return
+// an independent heap holding the same elements in the same order, so that
+// pushing to or popping from either heap does not affect the other. Elements
+// may be shared between the two if they are not modified in place.
+type Cloner interface {
+	Clone() Interface
+}
+
+// Clone returns a copy of h made by its Clone method, or nil if h does not
+// implement Cloner.
+func Clone(h Interface) Interface {
+	if c, ok := h.(Cloner); ok {
+		return c.Clone()
+	}
+	return nil
+}
+
+func checkIndex(op string, h Interface, i int) {
+	if n := h.Len(); i < 0 || i >= n {
+		panic(fmt.Sprintf("minmaxheap: %s index %d out of range [0,%d)", op, i, n))
+	}
+}
+
+// checkNotEmpty panics if n, the length of a heap, is zero, for operations
+// that need at least one element rather than a particular index.
+func checkNotEmpty(op string, n int) {
+	if n == 0 {
+		panic("minmaxheap: " + op + " on empty heap")
+	}
+}
+
+// Level returns the depth of index i in the tree layout of a heap, that is
+// floor(log2(i+1)): the root at index 0 is on level 0, indexes 1 and 2 on
+// level 1, 3 through 6 on level 2, and so on. It panics if i is negative.
+func Level(i int) int {
+	if i < 0 {
+		panic(fmt.Sprintf("minmaxheap: Level of negative index %d", i))
+	}
+	return level(i)
+}
+
+// IsMinLevel reports whether index i is on a min level, which are the even
+// levels starting with the root. Each element on a min level is no greater
+// than any of its descendants, and each element on a max level is no less
+// than any of its descendants. It panics if i is negative.
+func IsMinLevel(i int) bool {
+	return Level(i)%2 == 0
+}
+
 func level(i int) int {
 	// floor(log2(i + 1))
 	return bits.Len(uint(i)+1) - 1
@@ -59,12 +178,17 @@ func down(h Interface, i, n int) bool {
 		if l >= n || l < 0 /* overflow */ {
 			break
 		}
-		if h.Less(l, m) == min {
+
+		// A child that has children of its own is on the opposite kind of
+		// level from them, so it can never be more extreme than they are
+		// and need not be compared. This saves over a quarter of the Less
+		// calls when draining a random heap; see BenchmarkComparisons.
+		if lchild(l) >= n && h.Less(l, m) == min {
 			m = l
 		}
 
 		r := rchild(i)
-		if r < n && h.Less(r, m) == min {
+		if r < n && lchild(r) >= n && h.Less(r, m) == min {
 			m = r
 		}
 
@@ -82,6 +206,7 @@ func down(h Interface, i, n int) bool {
 		h.Swap(i, m)
 
 		if m == l || m == r {
+			i = m
 			break
 		}
 
@@ -95,8 +220,9 @@ func down(h Interface, i, n int) bool {
 	return i > i0
 }
 
-func up(h Interface, i int) {
+func up(h Interface, i int) bool {
 	min := isMinLevel(i)
+	i0 := i
 
 	if hasParent(i) {
 		p := parent(i)
@@ -110,12 +236,35 @@ func up(h Interface, i int) {
 	for hasGrandparent(i) {
 		g := grandparent(i)
 		if h.Less(i, g) != min {
-			return
+			break
 		}
 
 		h.Swap(i, g)
 		i = g
 	}
+	return i < i0
+}
+
+// SiftUp moves the element at index i toward the root until it is in order
+// with its ancestors. It assumes that the heap invariants hold everywhere
+// except between that element and its ancestors, as after appending an
+// element; Push is h.Push followed by SiftUp(h, h.Len()-1). It is the
+// primitive behind Push and FixUp, without their bounds checks, for building
+// custom operations.
+// The complexity is O(log n) where n = h.Len().
+func SiftUp(h Interface, i int) {
+	up(h, i)
+}
+
+// SiftDown moves the element at index i away from the root until it is in
+// order with its descendants. It assumes that the subtrees below i are valid
+// min-max heaps and that the element is in order with its ancestors, as after
+// replacing the root; Pop is a swap of the root with the last element, h.Pop,
+// and then SiftDown(h, 0). It is the primitive behind Pop, Init and FixDown,
+// without their bounds checks, for building custom operations.
+// The complexity is O(log n) where n = h.Len().
+func SiftDown(h Interface, i int) {
+	down(h, i, h.Len())
 }
 
 // Init establishes the heap invariants required by the other routines in this
@@ -129,6 +278,14 @@ func Init(h Interface) {
 	}
 }
 
+// InitReserve is like Init, but first lets h preallocate room for growTo
+// elements if it implements Reserver.
+// The complexity is O(n) where n = h.Len().
+func InitReserve(h Interface, growTo int) {
+	Reserve(h, growTo)
+	Init(h)
+}
+
 // Push pushes the element x onto the heap.
 // The complexity is O(log n) where n = h.Len().
 func Push(h Interface, x interface{}) {
@@ -149,33 +306,163 @@ func Pop(h Interface) interface{} {
 // The complexity is O(log n) where n = h.Len().
 func PopMax(h Interface) interface{} {
 	n := h.Len()
+	i := maxIndex(h)
+	h.Swap(i, n-1)
+	down(h, i, n-1)
+	return h.Pop()
+}
 
-	i := 0
-	l := lchild(0)
-	if l < n && !h.Less(l, i) {
-		i = l
+// PopMinMax removes and returns both the minimum and the maximum element. If
+// the heap has one element, it is removed and returned as both. It panics if
+// the heap is empty.
+//
+// Both elements are moved to the end before either is popped, and the two
+// elements they displace are sifted down within the remaining n-2, so the
+// heap is never rebalanced at its intermediate length as with Pop followed by
+// PopMax.
+// The complexity is O(log n) where n = h.Len().
+func PopMinMax(h Interface) (smallest, largest interface{}) {
+	checkNotEmpty("PopMinMax", h.Len())
+	n := h.Len()
+	if n == 1 {
+		x := h.Pop()
+		return x, x
+	}
+	// with the maximum at n-1 and the minimum at n-2, the vacated root and
+	// index m hold the displaced elements; m is a child of the root, so it
+	// is sifted first, leaving the root with valid subtrees as in Init
+	m := maxIndex(h)
+	h.Swap(m, n-1)
+	h.Swap(0, n-2)
+	if m < n-2 {
+		down(h, m, n-2)
 	}
+	down(h, 0, n-2)
+	largest = h.Pop()
+	smallest = h.Pop()
+	return smallest, largest
+}
+
+// PushPop pushes x onto the heap and then removes and returns the minimum
+// element, which is x itself if the heap is empty or x is not greater than
+// the current minimum. The length of the heap is unchanged. It is more
+// efficient than Push followed by Pop, as it sifts at most once.
+// The complexity is O(log n) where n = h.Len().
+func PushPop(h Interface, x interface{}) interface{} {
+	y, _ := pushPop(h, x)
+	return y
+}
 
-	r := rchild(0)
-	if r < n && !h.Less(r, i) {
-		i = r
+// pushPop implements PushPop, also reporting whether x was kept in the heap,
+// that is, whether the returned element is the previous minimum rather than x.
+func pushPop(h Interface, x interface{}) (y interface{}, kept bool) {
+	h.Push(x)
+	n := h.Len() - 1
+	if n > 0 && h.Less(0, n) {
+		h.Swap(0, n)
+		down(h, 0, n)
+		kept = true
 	}
+	return h.Pop(), kept
+}
 
-	h.Swap(i, n-1)
-	down(h, i, n-1)
-	return h.Pop()
+// Replace removes and returns the minimum element and pushes x in its place,
+// keeping the length of the heap unchanged. Unlike PushPop, x is always kept,
+// even if it is the new minimum. It is more efficient than Pop followed by
+// Push, as it sifts once. It panics if the heap is empty.
+// The complexity is O(log n) where n = h.Len().
+func Replace(h Interface, x interface{}) interface{} {
+	checkNotEmpty("Replace", h.Len())
+	return replace(h, 0, x)
+}
+
+// ReplaceMax is like Replace, but removes and returns the maximum element.
+// It panics if the heap is empty.
+// The complexity is O(log n) where n = h.Len().
+func ReplaceMax(h Interface, x interface{}) interface{} {
+	checkNotEmpty("ReplaceMax", h.Len())
+	return replace(h, maxIndex(h), x)
+}
+
+// replace swaps x in for the element at index i, which must be the minimum or
+// the maximum, and returns the old element.
+func replace(h Interface, i int, x interface{}) interface{} {
+	h.Push(x)
+	n := h.Len() - 1
+	h.Swap(i, n)
+	old := h.Pop()
+	// only the maximum can be below its parent, the root
+	up(h, i)
+	down(h, i, n)
+	return old
+}
+
+// PeekMin returns the minimum element (according to Less) without removing it.
+// It panics if the heap is empty. Unless h implements Indexer, reading the
+// element calls h's Pop and Push, after which h is as it was; see Indexer.
+// The complexity is O(1).
+func PeekMin(h Interface) interface{} {
+	checkNotEmpty("PeekMin", h.Len())
+	return element(h, 0)
+}
+
+// PeekMax returns the maximum element (according to Less) without removing it.
+// It panics if the heap is empty. Like PeekMin, it calls h's Pop and Push
+// unless h implements Indexer.
+// The complexity is O(1).
+func PeekMax(h Interface) interface{} {
+	checkNotEmpty("PeekMax", h.Len())
+	return element(h, maxIndex(h))
 }
 
 // Remove removes and returns the element at index i from the heap.
 // The complexity is O(log n) where n = h.Len().
 func Remove(h Interface, i int) interface{} {
+	checkIndex("Remove", h, i)
+	x, _ := remove(h, i)
+	return x
+}
+
+// remove removes and returns the element at index i, which must be in range,
+// and reports whether the last element, moved into its place, was then sifted.
+func remove(h Interface, i int) (x interface{}, moved bool) {
+	moved = removeToEnd(h, i)
+	return h.Pop(), moved
+}
+
+// removeToEnd moves the element at index i, which must be in range, to the
+// end of h and restores the heap invariants among the elements before it,
+// leaving it to be popped. It reports whether the last element, moved into
+// its place, was then sifted.
+func removeToEnd(h Interface, i int) (moved bool) {
 	n := h.Len() - 1
 	if n != i {
 		h.Swap(i, n)
-		up(h, i)
-		down(h, i, n)
+		movedUp := up(h, i)
+		moved = down(h, i, n) || movedUp
 	}
-	return h.Pop()
+	return moved
+}
+
+// TryRemove is like Remove, but returns false instead of panicking if h is
+// empty, as reported by IsEmpty, or i is out of range.
+// The complexity is O(log n) where n = h.Len().
+func TryRemove(h Interface, i int) (interface{}, bool) {
+	if IsEmpty(h) || i < 0 || i >= h.Len() {
+		return nil, false
+	}
+	x, _ := remove(h, i)
+	return x, true
+}
+
+// RemoveInfo is like Remove, but also reports whether the heap had to be
+// rebalanced: whether the last element, which Remove moves into the gap, was
+// then sifted up or down. It is false when i is the last index, or when the
+// last element already fits at i.
+// The complexity is O(log n) where n = h.Len().
+func RemoveInfo(h Interface, i int) (x interface{}, rebalanced bool) {
+	checkIndex("RemoveInfo", h, i)
+	return remove(h, i)
 }
 
 // Fix re-establishes the heap ordering after the element at index i has
@@ -184,6 +471,49 @@ func Remove(h Interface, i int) interface{} {
 // followed by a Push of the new value.
 // The complexity is O(log n) where n = h.Len().
 func Fix(h Interface, i int) {
+	checkIndex("Fix", h, i)
 	up(h, i)
 	down(h, i, h.Len())
 }
+
+// FixUp is like Fix, but only moves the element at index i toward the root,
+// saving the comparisons Fix spends looking below it. It is sufficient when
+// the element has moved toward the extreme of its own level: decreased on a
+// min level or increased on a max level (see IsMinLevel), as it then still
+// bounds its descendants.
+// The complexity is O(log n) where n = h.Len().
+func FixUp(h Interface, i int) {
+	checkIndex("FixUp", h, i)
+	up(h, i)
+}
+
+// FixDown is like Fix, but only moves the element at index i away from the
+// root, saving the comparisons Fix spends looking above it. It is sufficient
+// when the element has moved away from the extreme of its own level without
+// passing its parent: increased on a min level but still no greater than its
+// parent, or decreased on a max level but still no less than its parent. The
+// root has no parent, so any change to it that is not a decrease needs only
+// FixDown.
+// The complexity is O(log n) where n = h.Len().
+func FixDown(h Interface, i int) {
+	checkIndex("FixDown", h, i)
+	down(h, i, h.Len())
+}
+
+type reverse struct {
+	// This embedded Interface permits Reverse to use the methods of
+	// another Interface implementation.
+	Interface
+}
+
+// Less returns the opposite of the embedded implementation's Less method.
+func (r reverse) Less(i, j int) bool {
+	return r.Interface.Less(j, i)
+}
+
+// Reverse returns the reverse order for h, so that Pop returns the maximum
+// and PopMax the minimum. The returned Interface shares h's backing data, so
+// Init must be called on it before any other operation.
+func Reverse(h Interface) Interface {
+	return reverse{h}
+}
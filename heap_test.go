@@ -8,8 +8,8 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
-	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -39,7 +39,7 @@ func TestMain(m *testing.M) {
 }
 
 // newTestRand creates a deterministic *rand.Rand for the given test based on the test name.
-func newTestRand(t *testing.T) *rand.Rand {
+func newTestRand(t testing.TB) *rand.Rand {
 	randMu.Lock()
 	defer randMu.Unlock()
 
@@ -68,6 +68,31 @@ func (h *myHeap) Push(x interface{}) {
 	*h = append(*h, x.(int))
 }
 
+// Clone implements Cloner. It copies the backing slice, so that the clone
+// does not share an array with h; reslicing alone would let a Push on one
+// heap overwrite elements of the other.
+func (h *myHeap) Clone() Interface {
+	c := make(myHeap, len(*h))
+	copy(c, *h)
+	return &c
+}
+
+// Reserve implements Reserver.
+func (h *myHeap) Reserve(n int) {
+	if n > cap(*h) {
+		grown := make(myHeap, len(*h), n)
+		copy(grown, *h)
+		*h = grown
+	}
+}
+
+// Reset implements Resetter. It keeps the backing array, so the elements
+// past the new length are unreachable but not freed; a heap of pointers
+// should also zero them so they can be collected.
+func (h *myHeap) Reset() {
+	*h = (*h)[:0]
+}
+
 func (h myHeap) verify(t *testing.T, i int) {
 	t.Helper()
 	n := h.Len()
@@ -110,7 +135,7 @@ func (h myHeap) Format(t *testing.T, highlight int) (filename string) {
 	}
 
 	// Generate SVG representation of the heap
-	svgContent := h.FormatSVG(highlight)
+	svgContent := FormatSVG(&h, highlight)
 
 	// Create the output directory if it doesn't exist
 	outputDir := "heap_visualizations"
@@ -132,185 +157,6 @@ func (h myHeap) Format(t *testing.T, highlight int) (filename string) {
 	return filename
 }
 
-// FormatSVG generates an SVG representation of the heap tree
-func (h myHeap) FormatSVG(highlight int) string {
-	// Determine SVG parameters based on heap size
-	var nodeDiameter, levelHeight, leftMargin, topMargin int
-
-	// Adjust parameters based on heap size
-	if h.Len() <= 31 { // Small heap (5 levels or fewer)
-		nodeDiameter = 40
-		levelHeight = 80
-		leftMargin = 10
-		topMargin = 20
-	} else if h.Len() <= 127 { // Medium heap (6-7 levels)
-		nodeDiameter = 30
-		levelHeight = 60
-		leftMargin = 10
-		topMargin = 20
-	} else { // Large heap (8+ levels)
-		nodeDiameter = 24
-		levelHeight = 50
-		leftMargin = 10
-		topMargin = 20
-	}
-
-	// Calculate the total width needed for the tree
-	levels := level(h.Len())
-
-	// For very large heaps, limit the width by not showing all levels
-	maxLevelsToShow := levels
-	if levels > 7 {
-		maxLevelsToShow = 7 // Only show top 7 levels for very large heaps
-	}
-
-	maxNodesInLevel := 1 << maxLevelsToShow // Maximum nodes in the last level we'll show
-	totalWidth := maxNodesInLevel*(nodeDiameter*2) + leftMargin*2
-	totalHeight := (maxLevelsToShow+1)*levelHeight + topMargin*2
-
-	// Start the SVG
-	var buf strings.Builder
-	buf.WriteString(fmt.Sprintf("<svg width=\"%d\" height=\"%d\" xmlns=\"http://www.w3.org/2000/svg\">\n",
-		totalWidth, totalHeight))
-
-	// Add a title
-	buf.WriteString(fmt.Sprintf("  <title>MinMaxHeap Visualization (%d nodes)</title>\n", h.Len()))
-
-	// Style definitions
-	buf.WriteString(`  <style>
-    .node-min { fill: lightblue; stroke: #333; stroke-width: 2; }
-    .node-max { fill: lightpink; stroke: #333; stroke-width: 2; }
-    .node-highlight { stroke: red; stroke-width: 3; }
-    .node-text { font-family: Arial; font-size: 14px; text-anchor: middle; dominant-baseline: middle; }
-    .node-index { font-family: Arial; font-size: 10px; text-anchor: middle; fill: #666; }
-    .edge { stroke: #666; stroke-width: 1.5; fill: none; }
-    .legend { font-family: Arial; font-size: 12px; }
-  </style>
-`)
-
-	// Add a legend
-	buf.WriteString(`  <!-- Legend -->
-  <rect x="10" y="10" width="15" height="15" class="node-min" />
-  <text x="30" y="22" class="legend">Min Level</text>
-  <rect x="100" y="10" width="15" height="15" class="node-max" />
-  <text x="120" y="22" class="legend">Max Level</text>
-`)
-
-	// Calculate positions for each node
-	nodesCount := h.Len()
-	if maxLevelsToShow < levels {
-		// Calculate how many nodes we're showing if we limited levels
-		nodesCount = (1 << (maxLevelsToShow + 1)) - 1
-		if nodesCount > h.Len() {
-			nodesCount = h.Len()
-		}
-	}
-
-	nodePositions := make([]struct{ x, y int }, nodesCount)
-	for i := 0; i < nodesCount; i++ {
-		lev := level(i)
-		if lev > maxLevelsToShow {
-			continue // Skip nodes beyond our display level
-		}
-
-		nodesInLevel := 1 << lev
-
-		// Calculate width of this level
-		levelWidth := totalWidth - (leftMargin * 2)
-
-		// Calculate position in this level (0-based)
-		posInLevel := i - ((1 << lev) - 1)
-
-		// Calculate x position (centered in its segment)
-		segmentWidth := levelWidth / nodesInLevel
-		x := leftMargin + (posInLevel * segmentWidth) + (segmentWidth / 2)
-
-		// Calculate y position
-		y := topMargin + (lev * levelHeight) + (nodeDiameter / 2)
-
-		nodePositions[i] = struct{ x, y int }{x, y}
-	}
-
-	// Draw edges first (so they'll be behind nodes)
-	buf.WriteString("  <!-- Edges connecting nodes -->\n")
-	for i := 0; i < nodesCount; i++ {
-		lev := level(i)
-		if lev >= maxLevelsToShow {
-			continue // Skip drawing edges from nodes in the last level
-		}
-
-		leftChild := 2*i + 1
-		rightChild := 2*i + 2
-
-		if leftChild < nodesCount {
-			buf.WriteString(fmt.Sprintf("  <path class=\"edge\" d=\"M%d,%d C%d,%d %d,%d %d,%d\" />\n",
-				nodePositions[i].x, nodePositions[i].y+(nodeDiameter/2),
-				nodePositions[i].x, nodePositions[i].y+levelHeight/3,
-				nodePositions[leftChild].x, nodePositions[leftChild].y-levelHeight/3,
-				nodePositions[leftChild].x, nodePositions[leftChild].y-(nodeDiameter/2)))
-		}
-
-		if rightChild < nodesCount {
-			buf.WriteString(fmt.Sprintf("  <path class=\"edge\" d=\"M%d,%d C%d,%d %d,%d %d,%d\" />\n",
-				nodePositions[i].x, nodePositions[i].y+(nodeDiameter/2),
-				nodePositions[i].x, nodePositions[i].y+levelHeight/3,
-				nodePositions[rightChild].x, nodePositions[rightChild].y-levelHeight/3,
-				nodePositions[rightChild].x, nodePositions[rightChild].y-(nodeDiameter/2)))
-		}
-	}
-
-	// Draw all nodes
-	buf.WriteString("  <!-- Nodes -->\n")
-	for i := 0; i < nodesCount; i++ {
-		lev := level(i)
-		if lev > maxLevelsToShow {
-			continue // Skip nodes beyond our display level
-		}
-
-		x := nodePositions[i].x
-		y := nodePositions[i].y
-
-		// Determine node class based on min/max level
-		nodeClass := "node-min"
-		if !isMinLevel(i) {
-			nodeClass = "node-max"
-		}
-
-		// Add highlight class if needed
-		if i == highlight {
-			nodeClass += " node-highlight"
-		}
-
-		// Draw the node
-		buf.WriteString(fmt.Sprintf("  <circle cx=\"%d\" cy=\"%d\" r=\"%d\" class=\"%s\" />\n",
-			x, y, nodeDiameter/2, nodeClass))
-
-		// Add the value text
-		buf.WriteString(fmt.Sprintf("  <text x=\"%d\" y=\"%d\" class=\"node-text\">%d</text>\n",
-			x, y, h[i]))
-
-		// Add node index for all nodes as a reference
-		fontSize := 10
-		if nodeDiameter < 30 {
-			fontSize = 8 // Smaller font for smaller nodes
-		}
-
-		buf.WriteString(fmt.Sprintf("  <text x=\"%d\" y=\"%d\" dy=\"%d\" class=\"node-index\" font-size=\"%d\">[%d]</text>\n",
-			x, y, -nodeDiameter/2-2, fontSize, i))
-	}
-
-	// If we limited the display, add a note
-	if maxLevelsToShow < levels {
-		buf.WriteString(fmt.Sprintf("  <text x=\"%d\" y=\"%d\" class=\"legend\">Note: Only showing %d of %d levels. Total nodes: %d</text>\n",
-			totalWidth/2, totalHeight-20, maxLevelsToShow, levels, h.Len()))
-	}
-
-	// End the SVG
-	buf.WriteString("</svg>")
-
-	return buf.String()
-}
-
 func TestInit0(t *testing.T) {
 	h := new(myHeap)
 	for i := 20; i > 0; i-- {
@@ -615,8 +461,12 @@ func TestFix0(t *testing.T) {
 		} else {
 			(*h)[elem] /= 2
 		}
+		before := append(myHeap(nil), *h...)
 		Fix(h, elem)
 		h.verify(t, 0)
+		if err := CheckConservation(&before, h, intsEqual); err != nil {
+			t.Fatal(err)
+		}
 	}
 }
 
@@ -628,10 +478,753 @@ func TestFix1(t *testing.T) {
 		h.verify(t, 0)
 	}
 	(*h)[48] = -1
+	before := append(myHeap(nil), *h...)
 	Fix(h, 48)
 	h.verify(t, 0)
+	if err := CheckConservation(&before, h, intsEqual); err != nil {
+		t.Fatal(err)
+	}
 	got := Pop(h).(int)
 	if got != -1 {
 		t.Fatalf("expected -1 as minimum, got %d", got)
 	}
 }
+
+func TestReverse(t *testing.T) {
+	h := new(myHeap)
+	for i := 0; i < 20; i++ {
+		Push(h, i)
+	}
+
+	r := Reverse(h)
+	Init(r)
+	for i := 19; h.Len() > 0; i-- {
+		x := Pop(r).(int)
+		if x != i {
+			t.Errorf("Pop got %d; want %d", x, i)
+		}
+	}
+}
+
+func TestRemoveInfo(t *testing.T) {
+	h := new(myHeap)
+	for i := 0; i < 10; i++ {
+		Push(h, i)
+	}
+
+	want := (*h)[h.Len()-1]
+	x, rebalanced := RemoveInfo(h, h.Len()-1)
+	if x.(int) != want || rebalanced {
+		t.Errorf("RemoveInfo(last) = %d, %v; want %d, false", x, rebalanced, want)
+	}
+	h.verify(t, 0)
+
+	x, rebalanced = RemoveInfo(h, 0)
+	if x.(int) != 0 || !rebalanced {
+		t.Errorf("RemoveInfo(0) = %d, %v; want 0, true", x, rebalanced)
+	}
+	h.verify(t, 0)
+
+	// the last element, 4, already fits in place of 1
+	h = &myHeap{0, 10, 9, 1, 2, 3, 4}
+	h.verify(t, 0)
+	x, rebalanced = RemoveInfo(h, 3)
+	if x.(int) != 1 || rebalanced {
+		t.Errorf("RemoveInfo(3) = %d, %v; want 1, false", x, rebalanced)
+	}
+	h.verify(t, 0)
+
+	// the last element, 3, must sift below 4 in place of 10
+	x, rebalanced = RemoveInfo(h, 1)
+	if x.(int) != 10 || !rebalanced {
+		t.Errorf("RemoveInfo(1) = %d, %v; want 10, true", x, rebalanced)
+	}
+	h.verify(t, 0)
+}
+
+// emptierHeap counts calls to IsEmpty.
+type emptierHeap struct {
+	myHeap
+	calls int
+}
+
+func (h *emptierHeap) IsEmpty() bool {
+	h.calls++
+	return len(h.myHeap) == 0
+}
+
+func TestIsEmpty(t *testing.T) {
+	h := new(myHeap)
+	if !IsEmpty(h) {
+		t.Errorf("IsEmpty(empty) = false; want true")
+	}
+	Push(h, 1)
+	if IsEmpty(h) {
+		t.Errorf("IsEmpty(non-empty) = true; want false")
+	}
+
+	e := &emptierHeap{myHeap: myHeap{3, 1, 2}}
+	Init(e)
+	DrainAll(e)
+	if e.calls != 4 {
+		t.Errorf("DrainAll called IsEmpty %d times; want 4", e.calls)
+	}
+
+	e.calls = 0
+	if _, ok := TryRemove(e, 0); ok || e.calls != 1 {
+		t.Errorf("TryRemove(empty) = %v with %d IsEmpty calls; want false with 1", ok, e.calls)
+	}
+	Push(e, 7)
+	if x, ok := TryRemove(e, 0); !ok || x.(int) != 7 || e.calls != 2 {
+		t.Errorf("TryRemove(0) = %v, %v with %d IsEmpty calls; want 7, true with 2", x, ok, e.calls)
+	}
+}
+
+// TestDownGrandchildMin checks that sifting down from a min level considers
+// each of the four grandchildren, by placing the smallest element at each one
+// in turn.
+func TestDownGrandchildMin(t *testing.T) {
+	for target := 3; target <= 6; target++ {
+		h := &myHeap{50, 60, 60, 5, 5, 5, 5}
+		(*h)[target] = 1
+
+		Fix(h, 0)
+		h.verify(t, 0)
+		if (*h)[0] != 1 || (*h)[target] != 50 {
+			t.Errorf("target %d: got %v; want 1 at [0] and 50 at [%d]", target, *h, target)
+		}
+	}
+}
+
+// TestDownGrandchildMax checks that sifting down from a max level considers
+// each of the four grandchildren, by placing the largest element of the
+// subtree at each one in turn.
+func TestDownGrandchildMax(t *testing.T) {
+	for target := 7; target <= 10; target++ {
+		h := &myHeap{
+			0,
+			20, 100,
+			1, 1, 1, 1,
+			30, 30, 30, 30, 50, 50, 50, 50,
+		}
+		(*h)[target] = 40
+
+		Fix(h, 1)
+		h.verify(t, 0)
+		if (*h)[1] != 40 || (*h)[target] != 20 {
+			t.Errorf("target %d: got %v; want 40 at [1] and 20 at [%d]", target, *h, target)
+		}
+	}
+}
+
+// TestPopGrandchild checks that Pop and PopMax find the next extreme at each
+// grandchild position.
+func TestPopGrandchild(t *testing.T) {
+	for target := 3; target <= 6; target++ {
+		h := &myHeap{0, 60, 60, 5, 5, 5, 5, 9}
+		(*h)[target] = 2
+		h.verify(t, 0)
+
+		Pop(h)
+		h.verify(t, 0)
+		if (*h)[0] != 2 {
+			t.Errorf("target %d: after Pop got %v; want 2 at [0]", target, *h)
+		}
+	}
+
+	for target := 7; target <= 10; target++ {
+		h := &myHeap{
+			0,
+			90, 60,
+			1, 1, 1, 1,
+			30, 30, 30, 30, 30, 30, 30, 30,
+		}
+		(*h)[target] = 70
+		h.verify(t, 0)
+
+		PopMax(h)
+		h.verify(t, 0)
+		if (*h)[1] != 70 {
+			t.Errorf("target %d: after PopMax got %v; want 70 at [1]", target, *h)
+		}
+	}
+}
+
+func TestRemoveOutOfRange(t *testing.T) {
+	h := &myHeap{1, 2, 3}
+
+	for _, i := range []int{-1, 3, 5} {
+		if _, ok := TryRemove(h, i); ok {
+			t.Errorf("TryRemove(%d) succeeded", i)
+		}
+
+		func() {
+			want := fmt.Sprintf("minmaxheap: Remove index %d out of range [0,3)", i)
+			defer func() {
+				if r := recover(); r != want {
+					t.Errorf("Remove(%d) panicked with %v; want %q", i, r, want)
+				}
+			}()
+			Remove(h, i)
+		}()
+
+		func() {
+			want := fmt.Sprintf("minmaxheap: Fix index %d out of range [0,3)", i)
+			defer func() {
+				if r := recover(); r != want {
+					t.Errorf("Fix(%d) panicked with %v; want %q", i, r, want)
+				}
+			}()
+			Fix(h, i)
+		}()
+	}
+	if h.Len() != 3 {
+		t.Fatalf("Len() = %d after out-of-range calls; want 3", h.Len())
+	}
+
+	if x, ok := TryRemove(h, 0); !ok || x.(int) != 1 {
+		t.Errorf("TryRemove(0) = %v, %v; want 1, true", x, ok)
+	}
+}
+
+// BenchmarkOps measures a stream of operations on a heap kept around a given
+// size, for several mixes of operations.
+func BenchmarkOps(b *testing.B) {
+	type mix struct {
+		name   string
+		push   int // percentage of operations that push
+		popMax int // percentage of pops that are PopMax
+	}
+	mixes := []mix{
+		{"push-heavy", 75, 0},
+		{"pop-heavy", 25, 0},
+		{"mixed", 50, 50},
+		{"max-heavy", 50, 100},
+	}
+
+	for _, n := range []int{100, 10_000, 1_000_000} {
+		for _, m := range mixes {
+			b.Run(fmt.Sprintf("%s/%d", m.name, n), func(b *testing.B) {
+				rng := newTestRand(b)
+
+				type op struct {
+					push, max bool
+					x         int
+				}
+				ops := make([]op, 4096)
+				for i := range ops {
+					ops[i] = op{
+						push: rng.Intn(100) < m.push,
+						max:  rng.Intn(100) < m.popMax,
+						x:    rng.Intn(n),
+					}
+				}
+
+				h := make(myHeap, n, 2*n)
+				for i := range h {
+					h[i] = rng.Intn(n)
+				}
+				Init(&h)
+
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					o := ops[i%len(ops)]
+					push := o.push
+					// keep the size between n/2 and 2n
+					if h.Len() <= n/2 {
+						push = true
+					} else if h.Len() >= 2*n {
+						push = false
+					}
+					switch {
+					case push:
+						Push(&h, o.x)
+					case o.max:
+						PopMax(&h)
+					default:
+						Pop(&h)
+					}
+				}
+			})
+		}
+	}
+}
+
+// countingHeap counts calls to Less.
+type countingHeap struct {
+	myHeap
+	less int
+}
+
+func (h *countingHeap) Less(i, j int) bool {
+	h.less++
+	return h.myHeap.Less(i, j)
+}
+
+// TestComparisonCount checks the number of Less calls made by Pop and PopMax
+// against MaxComparisons.
+func TestComparisonCount(t *testing.T) {
+	rng := newTestRand(t)
+
+	for n := 1; n <= 1000; n++ {
+		h := new(countingHeap)
+		for i := 0; i < n; i++ {
+			h.myHeap = append(h.myHeap, rng.Intn(n))
+		}
+		Init(h)
+
+		max := n > 1 && rng.Intn(2) == 0
+		h.less = 0
+		if max {
+			PopMax(h)
+		} else {
+			Pop(h)
+		}
+		h.myHeap.verify(t, 0)
+
+		op := "Pop"
+		if max {
+			op = "PopMax"
+		}
+		bound := MaxComparisons(op, n)
+		if h.less > bound {
+			t.Errorf("n=%d max=%v: %d comparisons; want at most %d", n, max, h.less, bound)
+		}
+	}
+}
+
+// BenchmarkComparisons drains a random heap with Pop or PopMax and reports
+// the average number of Less calls per removal as less/op.
+func BenchmarkComparisons(b *testing.B) {
+	const n = 1 << 16
+	rng := rand.New(rand.NewSource(1))
+	xs := make(myHeap, n)
+	for i := range xs {
+		xs[i] = rng.Intn(n)
+	}
+
+	for _, op := range []string{"Pop", "PopMax"} {
+		b.Run(op, func(b *testing.B) {
+			h := new(countingHeap)
+			calls := 0
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				h.myHeap = append(h.myHeap[:0], xs...)
+				Init(h)
+				h.less = 0
+				b.StartTimer()
+
+				for h.Len() > 0 {
+					if op == "Pop" {
+						Pop(h)
+					} else {
+						PopMax(h)
+					}
+				}
+				calls += h.less
+			}
+			b.ReportMetric(float64(calls)/float64(b.N*n), "less/op")
+		})
+	}
+}
+
+func TestPeek(t *testing.T) {
+	rng := newTestRand(t)
+
+	for n := 1; n < 50; n++ {
+		h := new(myHeap)
+		for i := 0; i < n; i++ {
+			Push(h, rng.Intn(100))
+		}
+		before := append(myHeap(nil), *h...)
+		sorted := append([]int(nil), *h...)
+		sort.Ints(sorted)
+
+		if x := PeekMin(h).(int); x != sorted[0] {
+			t.Errorf("n=%d: PeekMin = %d; want %d", n, x, sorted[0])
+		}
+		if x := PeekMax(h).(int); x != sorted[n-1] {
+			t.Errorf("n=%d: PeekMax = %d; want %d", n, x, sorted[n-1])
+		}
+		for i := range before {
+			if (*h)[i] != before[i] {
+				t.Fatalf("n=%d: peeking changed [%d]", n, i)
+			}
+		}
+	}
+
+	for name, op := range map[string]func(Interface){
+		"PeekMin":    func(h Interface) { PeekMin(h) },
+		"PeekMax":    func(h Interface) { PeekMax(h) },
+		"Replace":    func(h Interface) { Replace(h, 1) },
+		"ReplaceMax": func(h Interface) { ReplaceMax(h, 1) },
+		"PopMinMax":  func(h Interface) { PopMinMax(h) },
+	} {
+		func() {
+			defer func() {
+				want := "minmaxheap: " + name + " on empty heap"
+				if r := recover(); r != want {
+					t.Errorf("%s on an empty heap panicked with %v; want %q", name, r, want)
+				}
+			}()
+			op(new(myHeap))
+		}()
+	}
+}
+
+func TestPushPop(t *testing.T) {
+	rng := newTestRand(t)
+
+	if x := PushPop(new(myHeap), 5).(int); x != 5 {
+		t.Errorf("PushPop on an empty heap = %d; want 5", x)
+	}
+
+	// x not greater than the minimum comes straight back
+	a := &myHeap{3, 9, 7}
+	for _, x := range []int{1, 3} {
+		if got := PushPop(a, x).(int); got != x || !slices.Equal(*a, myHeap{3, 9, 7}) {
+			t.Errorf("PushPop(%d) = %d, heap %v; want %d, [3 9 7]", x, got, *a, x)
+		}
+	}
+	if got := PushPop(a, 8).(int); got != 3 || a.Len() != 3 {
+		t.Errorf("PushPop(8) = %d, Len %d; want 3, 3", got, a.Len())
+	}
+	a.verify(t, 0)
+
+	h := new(myHeap)
+	ref := new(myHeap)
+	for i := 0; i < 50; i++ {
+		x := rng.Intn(100)
+		Push(h, x)
+		Push(ref, x)
+	}
+	for i := 0; i < 500; i++ {
+		x := rng.Intn(120)
+		got := PushPop(h, x).(int)
+		Push(ref, x)
+		want := Pop(ref).(int)
+		if got != want {
+			t.Fatalf("PushPop(%d) = %d; want %d", x, got, want)
+		}
+		if h.Len() != 50 {
+			t.Fatalf("Len() = %d after PushPop; want 50", h.Len())
+		}
+		h.verify(t, 0)
+	}
+}
+
+func BenchmarkPushPop(b *testing.B) {
+	const n = 10000
+	fill := func() myHeap {
+		h := make(myHeap, 0, n+1)
+		for j := 0; j < n; j++ {
+			h = append(h, j)
+		}
+		Init(&h)
+		return h
+	}
+
+	b.Run("PushPop", func(b *testing.B) {
+		h := fill()
+		for i := 0; i < b.N; i++ {
+			PushPop(&h, i%(2*n))
+		}
+	})
+	b.Run("Push+Pop", func(b *testing.B) {
+		h := fill()
+		for i := 0; i < b.N; i++ {
+			Push(&h, i%(2*n))
+			Pop(&h)
+		}
+	})
+}
+
+func TestReplace(t *testing.T) {
+	rng := newTestRand(t)
+
+	h := new(myHeap)
+	ref := new(myHeap)
+	for i := 0; i < 50; i++ {
+		x := rng.Intn(100)
+		Push(h, x)
+		Push(ref, x)
+	}
+	for i := 0; i < 500; i++ {
+		x := rng.Intn(120) - 10
+		var got, want int
+		if i%2 == 0 {
+			got = Replace(h, x).(int)
+			want = Pop(ref).(int)
+		} else {
+			got = ReplaceMax(h, x).(int)
+			want = PopMax(ref).(int)
+		}
+		Push(ref, x)
+		if got != want {
+			t.Fatalf("replace %d: got %d; want %d", i, got, want)
+		}
+		if h.Len() != 50 {
+			t.Fatalf("Len() = %d after replace; want 50", h.Len())
+		}
+		h.verify(t, 0)
+	}
+
+	single := &myHeap{3}
+	if x := ReplaceMax(single, 7).(int); x != 3 || (*single)[0] != 7 {
+		t.Errorf("ReplaceMax on one element = %d, left %v", x, *single)
+	}
+
+	for _, replace := range []func(Interface, interface{}) interface{}{Replace, ReplaceMax} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("replacing in an empty heap did not panic")
+				}
+			}()
+			replace(new(myHeap), 1)
+		}()
+	}
+}
+
+func TestClone(t *testing.T) {
+	rng := newTestRand(t)
+
+	h := new(myHeap)
+	for i := 0; i < 50; i++ {
+		Push(h, rng.Intn(100))
+	}
+	before := append(myHeap(nil), *h...)
+
+	c := Clone(h)
+	if c == nil {
+		t.Fatal("Clone returned nil for a Cloner")
+	}
+	for c.Len() > 0 {
+		Pop(c)
+	}
+	Push(c, -1)
+	if !slices.Equal(*h, before) {
+		t.Errorf("changing the clone changed the original: %v; want %v", *h, before)
+	}
+
+	if c := Clone(struct{ Interface }{h}); c != nil {
+		t.Errorf("Clone of a non-Cloner = %v; want nil", c)
+	}
+}
+
+func TestLevel(t *testing.T) {
+	for _, tc := range []struct {
+		i, level int
+	}{
+		{0, 0}, {1, 1}, {2, 1}, {3, 2}, {6, 2}, {7, 3}, {14, 3}, {15, 4},
+		{1<<20 - 2, 19}, {1<<20 - 1, 20}, {1 << 20, 20},
+	} {
+		if got := Level(tc.i); got != tc.level {
+			t.Errorf("Level(%d) = %d; want %d", tc.i, got, tc.level)
+		}
+		if got, want := IsMinLevel(tc.i), tc.level%2 == 0; got != want {
+			t.Errorf("IsMinLevel(%d) = %v; want %v", tc.i, got, want)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Level(-1) did not panic")
+		}
+	}()
+	Level(-1)
+}
+
+func TestReserve(t *testing.T) {
+	h := &myHeap{3, 1, 2}
+	Init(h)
+	before := append(myHeap(nil), *h...)
+	Reserve(h, 100)
+	if cap(*h) < 100 || !slices.Equal(*h, before) {
+		t.Errorf("after Reserve(100): cap %d, %v; want cap >= 100, %v", cap(*h), *h, before)
+	}
+	Reserve(h, 1) // never shrinks
+	if cap(*h) < 100 {
+		t.Errorf("Reserve(1) shrank cap to %d", cap(*h))
+	}
+
+	// a heap without Reserve is left alone
+	Reserve(struct{ Interface }{h}, 1000)
+	if cap(*h) >= 1000 {
+		t.Errorf("Reserve through a non-Reserver grew cap to %d", cap(*h))
+	}
+}
+
+func BenchmarkReserve(b *testing.B) {
+	const n = 100_000
+	for _, reserve := range []bool{false, true} {
+		b.Run(fmt.Sprintf("reserve=%v", reserve), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var h myHeap
+				if reserve {
+					Reserve(&h, n)
+				}
+				for j := 0; j < n; j++ {
+					Push(&h, j%256) // small ints are not boxed
+				}
+			}
+		})
+	}
+}
+
+func TestClear(t *testing.T) {
+	h := &myHeap{1, 5, 3}
+	c := cap(*h)
+	Clear(h)
+	if h.Len() != 0 || cap(*h) != c {
+		t.Errorf("after Clear: Len %d, cap %d; want 0, %d", h.Len(), cap(*h), c)
+	}
+	Clear(h) // already empty
+	if h.Len() != 0 {
+		t.Errorf("Clear of an empty heap left Len %d", h.Len())
+	}
+
+	// without Resetter the elements are popped instead
+	h = &myHeap{1, 5, 3}
+	Clear(struct{ Interface }{h})
+	if h.Len() != 0 {
+		t.Errorf("Clear of a non-Resetter left Len %d", h.Len())
+	}
+	Clear(struct{ Interface }{h})
+	Push(h, 4)
+	h.verify(t, 0)
+}
+
+func TestFixUpDown(t *testing.T) {
+	rng := newTestRand(t)
+
+	h := new(myHeap)
+	for i := 0; i < 200; i++ {
+		Push(h, rng.Intn(1000))
+	}
+	for step := 0; step < 1000; step++ {
+		i := rng.Intn(h.Len())
+		x := (*h)[i]
+		// toward the extreme of i's level, where FixUp suffices, or away
+		// from it without passing the parent, where FixDown does
+		toward := rng.Intn(2) == 0
+		switch onMin := isMinLevel(i); {
+		case toward && onMin:
+			x -= rng.Intn(200)
+		case toward:
+			x += rng.Intn(200)
+		case onMin && i == 0:
+			x += rng.Intn(200)
+		case onMin:
+			x += rng.Intn((*h)[parent(i)] - x + 1)
+		default:
+			x -= rng.Intn(x - (*h)[parent(i)] + 1)
+		}
+		(*h)[i] = x
+		if toward {
+			FixUp(h, i)
+		} else {
+			FixDown(h, i)
+		}
+		h.verify(t, 0)
+	}
+}
+
+func TestSift(t *testing.T) {
+	rng := newTestRand(t)
+
+	// a push and pop built from the primitives behave like Push and Pop
+	h, ref := new(myHeap), new(myHeap)
+	for i := 0; i < 300; i++ {
+		if h.Len() > 0 && rng.Intn(3) == 0 {
+			h.Swap(0, h.Len()-1)
+			x := h.Pop().(int)
+			SiftDown(h, 0)
+			if want := Pop(ref).(int); x != want {
+				t.Fatalf("popped %d; want %d", x, want)
+			}
+		} else {
+			x := rng.Intn(100)
+			h.Push(x)
+			SiftUp(h, h.Len()-1)
+			Push(ref, x)
+		}
+		h.verify(t, 0)
+	}
+
+	// raising the minimum in place and sifting it down, as Replace does
+	for i := 0; i < 100 && h.Len() > 0; i++ {
+		(*h)[0] += rng.Intn(50)
+		SiftDown(h, 0)
+		h.verify(t, 0)
+	}
+}
+
+func TestPopMinMax(t *testing.T) {
+	rng := newTestRand(t)
+
+	for _, n := range []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 100, 101} {
+		h, ref := new(myHeap), new(myHeap)
+		for i := 0; i < n; i++ {
+			x := rng.Intn(50)
+			Push(h, x)
+			Push(ref, x)
+		}
+		for h.Len() > 0 {
+			lo, hi := PopMinMax(h)
+			wantLo := Pop(ref).(int)
+			wantHi := wantLo
+			if ref.Len() > 0 {
+				wantHi = PopMax(ref).(int)
+			}
+			if lo.(int) != wantLo || hi.(int) != wantHi {
+				t.Fatalf("n=%d: PopMinMax = %v, %v; want %d, %d", n, lo, hi, wantLo, wantHi)
+			}
+			if h.Len() != ref.Len() {
+				t.Fatalf("n=%d: Len() = %d; want %d", n, h.Len(), ref.Len())
+			}
+			h.verify(t, 0)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("PopMinMax of an empty heap did not panic")
+		}
+	}()
+	PopMinMax(new(myHeap))
+}
+
+// atHeap is a myHeap that implements Indexer and counts Push and Pop calls.
+type atHeap struct {
+	myHeap
+	calls int
+}
+
+func (h *atHeap) At(i int) interface{} { return h.myHeap[i] }
+
+func (h *atHeap) Push(x interface{}) { h.calls++; h.myHeap.Push(x) }
+
+func (h *atHeap) Pop() interface{} { h.calls++; return h.myHeap.Pop() }
+
+func TestPeekIndexer(t *testing.T) {
+	h := &atHeap{myHeap: myHeap{5, 1, 9, 3, 7}}
+	Init(h)
+	if x := PeekMin(h).(int); x != 1 {
+		t.Errorf("PeekMin = %d; want 1", x)
+	}
+	if x := PeekMax(h).(int); x != 9 {
+		t.Errorf("PeekMax = %d; want 9", x)
+	}
+	if h.calls != 0 {
+		t.Errorf("peeking an Indexer made %d Push or Pop calls; want 0", h.calls)
+	}
+
+	// without At, each peek pops and pushes once
+	PeekMin(struct{ Interface }{h})
+	if h.calls != 2 {
+		t.Errorf("peeking a non-Indexer made %d Push or Pop calls; want 2", h.calls)
+	}
+}
@@ -0,0 +1,167 @@
+package minmaxheap
+
+import "fmt"
+
+// Handle identifies an element pushed onto an IndexedHeap. It stays valid
+// until the element is removed, however the element moves within the heap.
+type Handle uint64
+
+// IndexedHeap is a min-max heap of elements of type T, ordered by a less
+// function, whose elements can be updated or removed through the Handle
+// returned when they were pushed. Each swap during sifting updates the
+// position recorded for the handles involved, so finding an element by its
+// handle needs no search.
+type IndexedHeap[T any] struct {
+	data indexedData[T]
+	next Handle
+}
+
+// NewIndexed returns an empty indexed heap ordered by less.
+func NewIndexed[T any](less func(a, b T) bool) *IndexedHeap[T] {
+	return &IndexedHeap[T]{data: indexedData[T]{
+		less: less,
+		pos:  make(map[Handle]int),
+	}}
+}
+
+// Len returns the number of elements in the heap.
+func (h *IndexedHeap[T]) Len() int {
+	return len(h.data.items)
+}
+
+// IsEmpty reports whether the heap has no elements.
+func (h *IndexedHeap[T]) IsEmpty() bool {
+	return len(h.data.items) == 0
+}
+
+// PushWithHandle pushes the element x onto the heap and returns its handle.
+// The complexity is O(log n) where n = h.Len().
+func (h *IndexedHeap[T]) PushWithHandle(x T) Handle {
+	hd := h.next
+	h.next++
+	d := &h.data
+	d.pos[hd] = len(d.items)
+	d.items = append(d.items, indexedItem[T]{x: x, handle: hd})
+	up(d, len(d.items)-1)
+	return hd
+}
+
+// Get returns the element with handle hd. It panics if hd is not in the heap.
+// The complexity is O(1).
+func (h *IndexedHeap[T]) Get(hd Handle) T {
+	return h.data.items[h.index("Get", hd)].x
+}
+
+// Contains reports whether the element with handle hd is still in the heap.
+func (h *IndexedHeap[T]) Contains(hd Handle) bool {
+	_, ok := h.data.pos[hd]
+	return ok
+}
+
+// Update replaces the element with handle hd by x and re-establishes the heap
+// ordering, whether x is less or greater than the old element. It panics if
+// hd is not in the heap.
+// The complexity is O(log n) where n = h.Len().
+func (h *IndexedHeap[T]) Update(hd Handle, x T) {
+	i := h.index("Update", hd)
+	h.data.items[i].x = x
+	up(&h.data, i)
+	down(&h.data, i, len(h.data.items))
+}
+
+// RemoveHandle removes and returns the element with handle hd. It panics if
+// hd is not in the heap.
+// The complexity is O(log n) where n = h.Len().
+func (h *IndexedHeap[T]) RemoveHandle(hd Handle) T {
+	return h.removeAt(h.index("RemoveHandle", hd))
+}
+
+// PopMin removes and returns the minimum element from the heap. It panics if
+// the heap is empty.
+// The complexity is O(log n) where n = h.Len().
+func (h *IndexedHeap[T]) PopMin() T {
+	checkNotEmpty("PopMin", len(h.data.items))
+	return h.removeAt(0)
+}
+
+// PopMax removes and returns the maximum element from the heap. It panics if
+// the heap is empty.
+// The complexity is O(log n) where n = h.Len().
+func (h *IndexedHeap[T]) PopMax() T {
+	checkNotEmpty("PopMax", len(h.data.items))
+	return h.removeAt(maxIndex(&h.data))
+}
+
+// PeekMin returns the minimum element without removing it. It panics if the
+// heap is empty.
+// The complexity is O(1).
+func (h *IndexedHeap[T]) PeekMin() T {
+	checkNotEmpty("PeekMin", len(h.data.items))
+	return h.data.items[0].x
+}
+
+// PeekMax returns the maximum element without removing it. It panics if the
+// heap is empty.
+// The complexity is O(1).
+func (h *IndexedHeap[T]) PeekMax() T {
+	checkNotEmpty("PeekMax", len(h.data.items))
+	return h.data.items[maxIndex(&h.data)].x
+}
+
+func (h *IndexedHeap[T]) index(op string, hd Handle) int {
+	i, ok := h.data.pos[hd]
+	if !ok {
+		panic(fmt.Sprintf("minmaxheap: %s of unknown handle %d", op, hd))
+	}
+	return i
+}
+
+// removeAt removes and returns the element at index i, forgetting its handle.
+func (h *IndexedHeap[T]) removeAt(i int) T {
+	removeToEnd(&h.data, i)
+	return h.data.pop().x
+}
+
+type indexedItem[T any] struct {
+	x      T
+	handle Handle
+}
+
+// indexedData adapts the items of an IndexedHeap to Interface, keeping pos
+// in step with every swap. Like heapData, Push and Pop exist only to satisfy
+// Interface.
+type indexedData[T any] struct {
+	items []indexedItem[T]
+	less  func(a, b T) bool
+	pos   map[Handle]int
+}
+
+func (d *indexedData[T]) Len() int           { return len(d.items) }
+func (d *indexedData[T]) Less(i, j int) bool { return d.less(d.items[i].x, d.items[j].x) }
+
+func (d *indexedData[T]) Swap(i, j int) {
+	d.items[i], d.items[j] = d.items[j], d.items[i]
+	d.pos[d.items[i].handle] = i
+	d.pos[d.items[j].handle] = j
+}
+
+func (d *indexedData[T]) Push(x interface{}) {
+	it := x.(indexedItem[T])
+	d.pos[it.handle] = len(d.items)
+	d.items = append(d.items, it)
+}
+
+func (d *indexedData[T]) Pop() interface{} {
+	return d.pop()
+}
+
+// pop removes and returns the last item without boxing it, forgetting its
+// handle.
+func (d *indexedData[T]) pop() indexedItem[T] {
+	n := len(d.items) - 1
+	it := d.items[n]
+	d.items[n] = indexedItem[T]{}
+	d.items = d.items[:n]
+	delete(d.pos, it.handle)
+	return it
+}
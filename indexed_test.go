@@ -0,0 +1,158 @@
+package minmaxheap
+
+import (
+	"cmp"
+	"sort"
+	"testing"
+)
+
+func (h *IndexedHeap[T]) verify(t testing.TB) {
+	t.Helper()
+	if vs := AllViolations(&h.data); len(vs) > 0 {
+		t.Fatalf("heap invariant violated: %v", vs)
+	}
+	if len(h.data.pos) != len(h.data.items) {
+		t.Fatalf("%d handles for %d elements", len(h.data.pos), len(h.data.items))
+	}
+	for i, it := range h.data.items {
+		if j := h.data.pos[it.handle]; j != i {
+			t.Fatalf("handle %d recorded at [%d]; element is at [%d]", it.handle, j, i)
+		}
+	}
+}
+
+func TestIndexedHeap(t *testing.T) {
+	rng := newTestRand(t)
+
+	h := NewIndexed(cmp.Less[int])
+	live := map[Handle]int{}
+	for i := 0; i < 200; i++ {
+		x := rng.Intn(1000)
+		live[h.PushWithHandle(x)] = x
+		h.verify(t)
+	}
+
+	for hd := range live {
+		switch rng.Intn(3) {
+		case 0:
+			x := rng.Intn(1000)
+			h.Update(hd, x)
+			live[hd] = x
+		case 1:
+			if x := h.RemoveHandle(hd); x != live[hd] {
+				t.Fatalf("RemoveHandle(%d) = %d; want %d", hd, x, live[hd])
+			}
+			if h.Contains(hd) {
+				t.Fatalf("Contains(%d) after RemoveHandle", hd)
+			}
+			delete(live, hd)
+		}
+		h.verify(t)
+	}
+	for hd, x := range live {
+		if got := h.Get(hd); got != x {
+			t.Fatalf("Get(%d) = %d; want %d", hd, got, x)
+		}
+	}
+
+	var want []int
+	for _, x := range live {
+		want = append(want, x)
+	}
+	sort.Ints(want)
+	for lo, hi := 0, len(want)-1; h.Len() > 0; {
+		if h.PeekMin() != want[lo] || h.PeekMax() != want[hi] {
+			t.Fatalf("PeekMin, PeekMax = %d, %d; want %d, %d", h.PeekMin(), h.PeekMax(), want[lo], want[hi])
+		}
+		if rng.Intn(2) == 0 {
+			if x := h.PopMin(); x != want[lo] {
+				t.Fatalf("PopMin = %d; want %d", x, want[lo])
+			}
+			lo++
+		} else {
+			if x := h.PopMax(); x != want[hi] {
+				t.Fatalf("PopMax = %d; want %d", x, want[hi])
+			}
+			hi--
+		}
+		h.verify(t)
+	}
+	if !h.IsEmpty() {
+		t.Errorf("IsEmpty after draining = false with Len %d", h.Len())
+	}
+}
+
+func TestIndexedHeapUnknownHandle(t *testing.T) {
+	h := NewIndexed(cmp.Less[int])
+	hd := h.PushWithHandle(1)
+	h.PopMin()
+	defer func() {
+		if recover() == nil {
+			t.Error("Update of a popped handle did not panic")
+		}
+	}()
+	h.Update(hd, 2)
+}
+
+// FuzzIndexedHeap interprets the input as a sequence of 3-byte operations:
+// an opcode, a selector for the handle, and a value.
+func FuzzIndexedHeap(f *testing.F) {
+	f.Add([]byte{0, 0, 5, 0, 0, 3, 1, 0, 9, 2, 1, 0})
+	f.Add([]byte{0, 0, 1, 0, 0, 2, 0, 0, 3, 3, 0, 0, 4, 0, 0, 1, 2, 7})
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		h := NewIndexed(cmp.Less[int])
+		var handles []Handle
+		live := map[Handle]int{}
+		for ; len(ops) >= 3; ops = ops[3:] {
+			op, sel, x := ops[0]%5, int(ops[1]), int(int8(ops[2]))
+			if len(handles) == 0 {
+				op = 0
+			}
+			switch op {
+			case 0:
+				hd := h.PushWithHandle(x)
+				handles = append(handles, hd)
+				live[hd] = x
+			case 1:
+				hd := handles[sel%len(handles)]
+				h.Update(hd, x)
+				live[hd] = x
+			case 2:
+				k := sel % len(handles)
+				hd := handles[k]
+				if got := h.RemoveHandle(hd); got != live[hd] {
+					t.Fatalf("RemoveHandle(%d) = %d; want %d", hd, got, live[hd])
+				}
+				delete(live, hd)
+				handles = append(handles[:k], handles[k+1:]...)
+			case 3, 4:
+				var got int
+				if op == 3 {
+					got = h.PopMin()
+				} else {
+					got = h.PopMax()
+				}
+				for _, v := range live {
+					if op == 3 && v < got || op == 4 && v > got {
+						t.Fatalf("popped %d while %d remains", got, v)
+					}
+				}
+				k := -1
+				for j, hd := range handles {
+					if !h.Contains(hd) {
+						k = j
+					}
+				}
+				if k < 0 || live[handles[k]] != got {
+					t.Fatalf("popped %d, which matches no removed handle", got)
+				}
+				delete(live, handles[k])
+				handles = append(handles[:k], handles[k+1:]...)
+			}
+			h.verify(t)
+			if h.Len() != len(live) {
+				t.Fatalf("Len() = %d; want %d", h.Len(), len(live))
+			}
+		}
+	})
+}
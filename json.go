@@ -0,0 +1,53 @@
+package minmaxheap
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// JSONHeap is implemented by heaps that FromJSON can decode into. NewElem
+// must return a pointer to a new zero element, such as new(int) for a heap of
+// ints, that encoding/json can decode into; the value it points to is then
+// passed to Push.
+type JSONHeap interface {
+	Interface
+	NewElem() interface{}
+}
+
+// ToJSON returns the elements of h as a JSON array in ascending order, each
+// encoded by encoding/json. h is left with the same elements; see Sorted.
+// The complexity is O(n log n) where n = h.Len().
+func ToJSON(h Interface) ([]byte, error) {
+	xs := Sorted(h)
+	if xs == nil {
+		xs = []interface{}{}
+	}
+	return json.Marshal(xs)
+}
+
+// FromJSON decodes data, which must be a JSON array or null, and pushes its
+// elements onto h, which then holds them together with any elements it
+// already had. The array need not be sorted: the heap invariants are
+// re-established with one Init. If decoding fails, h is left unchanged.
+// The complexity is O(n+m) where n = h.Len() and m is the number of decoded
+// elements.
+func FromJSON(h JSONHeap, data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	xs := make([]interface{}, len(raw))
+	for i, r := range raw {
+		p := h.NewElem()
+		if err := json.Unmarshal(r, p); err != nil {
+			return fmt.Errorf("minmaxheap: element %d: %w", i, err)
+		}
+		xs[i] = reflect.ValueOf(p).Elem().Interface()
+	}
+	for _, x := range xs {
+		h.Push(x)
+	}
+	Init(h)
+	return nil
+}
@@ -0,0 +1,80 @@
+package minmaxheap
+
+import "testing"
+
+func (h *myHeap) NewElem() interface{} { return new(int) }
+
+var _ JSONHeap = (*myHeap)(nil)
+
+func TestToJSON(t *testing.T) {
+	for _, tc := range []struct {
+		heap myHeap
+		want string
+	}{
+		{nil, "[]"},
+		{myHeap{7}, "[7]"},
+		{myHeap{1, 9, 8, 2, 3}, "[1,2,3,8,9]"},
+	} {
+		h := append(myHeap(nil), tc.heap...)
+		Init(&h)
+		got, err := ToJSON(&h)
+		if err != nil || string(got) != tc.want {
+			t.Errorf("ToJSON(%v) = %s, %v; want %s", tc.heap, got, err, tc.want)
+		}
+		if h.Len() != len(tc.heap) {
+			t.Errorf("ToJSON(%v) left %d elements", tc.heap, h.Len())
+		}
+	}
+}
+
+func TestFromJSON(t *testing.T) {
+	for _, tc := range []struct {
+		data string
+		want []int
+	}{
+		{"[]", nil},
+		{"null", nil},
+		{"[7]", []int{7}},
+		{"[5, 3, 9, 1, 4, 8]", []int{1, 3, 4, 5, 8, 9}},
+	} {
+		h := new(myHeap)
+		if err := FromJSON(h, []byte(tc.data)); err != nil {
+			t.Fatalf("FromJSON(%s): %v", tc.data, err)
+		}
+		h.verify(t, 0)
+		got := DrainAll(h)
+		if len(got) != len(tc.want) {
+			t.Fatalf("FromJSON(%s) = %v; want %v", tc.data, got, tc.want)
+		}
+		for i, x := range got {
+			if x.(int) != tc.want[i] {
+				t.Fatalf("FromJSON(%s) = %v; want %v", tc.data, got, tc.want)
+			}
+		}
+	}
+
+	// round trip, adding to existing elements
+	h := &myHeap{10, 20}
+	Init(h)
+	data, err := ToJSON(&myHeap{15, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := FromJSON(h, data); err != nil {
+		t.Fatal(err)
+	}
+	h.verify(t, 0)
+	if got, _ := ToJSON(h); string(got) != "[5,10,15,20]" {
+		t.Errorf("after FromJSON, ToJSON = %s; want [5,10,15,20]", got)
+	}
+
+	for _, bad := range []string{"", "{}", `[1, "two", 3]`, "[1,"} {
+		h := &myHeap{4}
+		if err := FromJSON(h, []byte(bad)); err == nil {
+			t.Errorf("FromJSON(%q) succeeded", bad)
+		}
+		if h.Len() != 1 {
+			t.Errorf("FromJSON(%q) failed but changed the heap to %v", bad, *h)
+		}
+	}
+}
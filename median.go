@@ -0,0 +1,73 @@
+package minmaxheap
+
+// Median tracks the median of a stream of elements of type T, ordered by a
+// less function. It keeps the lower half of the elements in one heap and the
+// upper half in another, using the max end of the first and the min end of
+// the second, and rebalances after each Add so that the lower half has the
+// same number of elements as the upper half or one more.
+type Median[T any] struct {
+	less   func(a, b T) bool
+	lo, hi *Heap[T]
+}
+
+// NewMedian returns a Median with no elements, ordered by less.
+func NewMedian[T any](less func(a, b T) bool) *Median[T] {
+	return &Median[T]{less: less, lo: New(less), hi: New(less)}
+}
+
+// Len returns the number of elements added.
+func (m *Median[T]) Len() int {
+	return m.lo.Len() + m.hi.Len()
+}
+
+// Add adds x to the stream.
+// The complexity is O(log n) where n = m.Len().
+func (m *Median[T]) Add(x T) {
+	if m.lo.Len() == 0 || !m.less(m.lo.PeekMax(), x) {
+		m.lo.Push(x)
+	} else {
+		m.hi.Push(x)
+	}
+	if m.lo.Len() > m.hi.Len()+1 {
+		m.hi.Push(m.lo.PopMax())
+	} else if m.hi.Len() > m.lo.Len() {
+		m.lo.Push(m.hi.PopMin())
+	}
+}
+
+// Value returns the median. For an even number of elements it returns the
+// lower of the two middle elements; see Middle and MedianMean for the other
+// choices. It panics if no elements have been added.
+// The complexity is O(1).
+func (m *Median[T]) Value() T {
+	checkNotEmpty("Value", len(m.lo.data.items))
+	return m.lo.PeekMax()
+}
+
+// Middle returns the two middle elements, lower first. For an odd number of
+// elements both are the median. It panics if no elements have been added.
+// The complexity is O(1).
+func (m *Median[T]) Middle() (lower, upper T) {
+	checkNotEmpty("Middle", len(m.lo.data.items))
+	lower = m.lo.PeekMax()
+	if m.hi.Len() == m.lo.Len() {
+		return lower, m.hi.PeekMin()
+	}
+	return lower, lower
+}
+
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// MedianMean returns the mean of the two middle elements of m, which for an
+// even number of elements is the usual definition of the median. It panics if
+// no elements have been added.
+// The complexity is O(1).
+func MedianMean[T Number](m *Median[T]) float64 {
+	lower, upper := m.Middle()
+	return float64(lower)/2 + float64(upper)/2
+}
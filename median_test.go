@@ -0,0 +1,53 @@
+package minmaxheap
+
+import (
+	"cmp"
+	"sort"
+	"testing"
+)
+
+func TestMedian(t *testing.T) {
+	rng := newTestRand(t)
+
+	const n = 500
+	for _, tc := range []struct {
+		name string
+		elem func(i int) int
+	}{
+		{"random", func(int) int { return rng.Intn(100) }},
+		{"ascending", func(i int) int { return i }},
+		{"descending", func(i int) int { return n - i }},
+	} {
+		m := NewMedian(cmp.Less[int])
+		var sorted []int
+		for i := 0; i < n; i++ {
+			x := tc.elem(i)
+			m.Add(x)
+			sorted = append(sorted, x)
+			sort.Ints(sorted)
+
+			lower, upper := sorted[(len(sorted)-1)/2], sorted[len(sorted)/2]
+			if got := m.Value(); got != lower {
+				t.Fatalf("%s: Value() after %d = %d; want %d", tc.name, len(sorted), got, lower)
+			}
+			if l, u := m.Middle(); l != lower || u != upper {
+				t.Fatalf("%s: Middle() after %d = %d, %d; want %d, %d", tc.name, len(sorted), l, u, lower, upper)
+			}
+			if got, want := MedianMean(m), float64(lower+upper)/2; got != want {
+				t.Fatalf("%s: MedianMean() after %d = %v; want %v", tc.name, len(sorted), got, want)
+			}
+			if m.Len() != len(sorted) || m.lo.Len()-m.hi.Len() > 1 || m.lo.Len() < m.hi.Len() {
+				t.Fatalf("%s: halves of %d and %d after %d", tc.name, m.lo.Len(), m.hi.Len(), len(sorted))
+			}
+		}
+	}
+}
+
+func TestMedianEmpty(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Value of an empty Median did not panic")
+		}
+	}()
+	NewMedian(cmp.Less[float64]).Value()
+}
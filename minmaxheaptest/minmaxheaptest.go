@@ -0,0 +1,63 @@
+// Package minmaxheaptest provides test assertions for min-max heaps built with
+// storj.io/minmaxheap.
+package minmaxheaptest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"storj.io/minmaxheap"
+)
+
+// AssertDrainsTo checks that popping every element of h with minmaxheap.Pop
+// yields want, in order. If h implements minmaxheap.Cloner, a clone is drained
+// and h is untouched; otherwise h is drained and rebuilt, so it keeps its
+// elements, though their arrangement may change. Elements are compared with
+// ==.
+func AssertDrainsTo(t testing.TB, h minmaxheap.Interface, want []interface{}) {
+	t.Helper()
+	assertDrains(t, "Pop", h, want, minmaxheap.DrainAll)
+}
+
+// AssertDrainsToMax is like AssertDrainsTo, but pops with minmaxheap.PopMax.
+func AssertDrainsToMax(t testing.TB, h minmaxheap.Interface, want []interface{}) {
+	t.Helper()
+	assertDrains(t, "PopMax", h, want, minmaxheap.DrainAllMax)
+}
+
+func assertDrains(t testing.TB, op string, h minmaxheap.Interface, want []interface{}, drain func(minmaxheap.Interface) []interface{}) {
+	t.Helper()
+
+	var got []interface{}
+	if c := minmaxheap.Clone(h); c != nil {
+		got = drain(c)
+	} else {
+		got = drain(h)
+		for _, x := range got {
+			h.Push(x)
+		}
+		minmaxheap.Init(h)
+	}
+
+	if diff := diff(want, got); diff != "" {
+		t.Errorf("draining with %s (-want +got):\n%s", op, diff)
+	}
+}
+
+// diff returns a line per position where want and got differ, or "" if they
+// are equal.
+func diff(want, got []interface{}) string {
+	var b strings.Builder
+	for i := 0; i < len(want) || i < len(got); i++ {
+		switch {
+		case i >= len(got):
+			fmt.Fprintf(&b, "\t[%d] -%v\n", i, want[i])
+		case i >= len(want):
+			fmt.Fprintf(&b, "\t[%d] +%v\n", i, got[i])
+		case want[i] != got[i]:
+			fmt.Fprintf(&b, "\t[%d] -%v +%v\n", i, want[i], got[i])
+		}
+	}
+	return b.String()
+}
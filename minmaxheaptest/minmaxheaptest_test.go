@@ -0,0 +1,77 @@
+package minmaxheaptest
+
+import (
+	"fmt"
+	"testing"
+
+	"storj.io/minmaxheap"
+)
+
+type intHeap []int
+
+func (h intHeap) Len() int            { return len(h) }
+func (h intHeap) Less(i, j int) bool  { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x interface{}) { *h = append(*h, x.(int)) }
+
+func (h *intHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+// recorder is a testing.TB that records failures instead of reporting them.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// cloneHeap is an intHeap that implements minmaxheap.Cloner.
+type cloneHeap struct{ intHeap }
+
+func (h *cloneHeap) Clone() minmaxheap.Interface {
+	return &cloneHeap{append(intHeap(nil), h.intHeap...)}
+}
+
+func TestAssertDrainsToClone(t *testing.T) {
+	h := &cloneHeap{intHeap{5, 3, 1, 4, 2}}
+	minmaxheap.Init(h)
+	before := append(intHeap(nil), h.intHeap...)
+
+	AssertDrainsTo(t, h, []interface{}{1, 2, 3, 4, 5})
+	AssertDrainsToMax(t, h, []interface{}{5, 4, 3, 2, 1})
+	for i := range before {
+		if h.intHeap[i] != before[i] {
+			t.Fatalf("asserting rearranged a Cloner: %v; want %v", h.intHeap, before)
+		}
+	}
+}
+
+func TestAssertDrainsTo(t *testing.T) {
+	h := &intHeap{5, 3, 1, 4, 2}
+	minmaxheap.Init(h)
+
+	AssertDrainsTo(t, h, []interface{}{1, 2, 3, 4, 5})
+	AssertDrainsToMax(t, h, []interface{}{5, 4, 3, 2, 1})
+	if h.Len() != 5 {
+		t.Fatalf("Len() = %d after asserting; want 5", h.Len())
+	}
+
+	r := &recorder{TB: t}
+	AssertDrainsTo(r, h, []interface{}{1, 2, 9, 4})
+	if len(r.errors) != 1 {
+		t.Fatalf("got %d failures; want 1", len(r.errors))
+	}
+	want := "draining with Pop (-want +got):\n\t[2] -9 +3\n\t[4] +5\n"
+	if r.errors[0] != want {
+		t.Errorf("failure message = %q; want %q", r.errors[0], want)
+	}
+}
@@ -0,0 +1,47 @@
+package minmaxheap
+
+import "sort"
+
+// PartialSort rearranges data so that its first k elements are the k smallest
+// in ascending order. The order of the remaining elements is unspecified. If k
+// is greater than data.Len(), all of data is sorted.
+//
+// The first k elements are kept as a bounded min-max heap: each later element
+// smaller than the heap's maximum replaces it. Only the k survivors are then
+// sorted, which is faster than sorting everything when k is much smaller than
+// data.Len().
+// The complexity is O(n log k) where n = data.Len().
+func PartialSort(data sort.Interface, k int) {
+	n := data.Len()
+	if k > n {
+		k = n
+	}
+	if k <= 0 {
+		return
+	}
+
+	h := prefix{data, k}
+	Init(h)
+	for j := k; j < n; j++ {
+		m := maxIndex(h)
+		if data.Less(j, m) {
+			data.Swap(j, m)
+			Fix(h, m)
+		}
+	}
+	sort.Sort(h)
+}
+
+// prefix is the first n elements of a sort.Interface, usable with the
+// functions in this package that do not change the length of the heap.
+type prefix struct {
+	data sort.Interface
+	n    int
+}
+
+func (p prefix) Len() int           { return p.n }
+func (p prefix) Less(i, j int) bool { return p.data.Less(i, j) }
+func (p prefix) Swap(i, j int)      { p.data.Swap(i, j) }
+
+func (p prefix) Push(x interface{}) { panic("minmaxheap: cannot push onto a prefix") }
+func (p prefix) Pop() interface{}   { panic("minmaxheap: cannot pop from a prefix") }
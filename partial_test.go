@@ -0,0 +1,63 @@
+package minmaxheap
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestPartialSort(t *testing.T) {
+	rng := newTestRand(t)
+
+	for _, n := range []int{0, 1, 2, 10, 100, 1000} {
+		for _, k := range []int{-1, 0, 1, 5, n / 2, n, n + 1} {
+			data := make([]int, n)
+			for i := range data {
+				data[i] = rng.Intn(n + 1)
+			}
+			want := append([]int(nil), data...)
+			sort.Ints(want)
+
+			PartialSort(sort.IntSlice(data), k)
+
+			m := k
+			if m > n {
+				m = n
+			}
+			for i := 0; i < m; i++ {
+				if data[i] != want[i] {
+					t.Fatalf("n=%d k=%d: data[%d] = %d; want %d", n, k, i, data[i], want[i])
+				}
+			}
+			sort.Ints(data)
+			for i := range data {
+				if data[i] != want[i] {
+					t.Fatalf("n=%d k=%d: PartialSort lost or duplicated elements", n, k)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkPartialSort(b *testing.B) {
+	rng := newTestRand(b)
+
+	const n, k = 100_000, 100
+	orig := make([]int, n)
+	for i := range orig {
+		orig[i] = rng.Int()
+	}
+	data := make([]int, n)
+
+	b.Run("PartialSort", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(data, orig)
+			PartialSort(sort.IntSlice(data), k)
+		}
+	})
+	b.Run("sort.Sort", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(data, orig)
+			sort.Sort(sort.IntSlice(data))
+		}
+	})
+}
@@ -0,0 +1,287 @@
+package minmaxheap
+
+// TopKEqual reports whether a and b hold the same multiset of k smallest
+// elements. If a heap has fewer than k elements, all of its elements are
+// compared. Elements are compared with ==, so they must be comparable, and a
+// and b must be distinct heaps. Elements that tie under Less may come off the
+// two heaps in different orders; they still compare equal as a multiset.
+//
+// The k smallest elements are popped from a clone of each heap that
+// implements Cloner. A heap that does not has them popped and pushed back
+// afterwards, so it holds the same elements as before, though possibly in a
+// different arrangement.
+// The complexity is O(k log n) where n = max(a.Len(), b.Len()).
+func TopKEqual(a, b Interface, k int) bool {
+	xs := peekK(a, k)
+	ys := peekK(b, k)
+	if len(xs) != len(ys) {
+		return false
+	}
+	counts := make(map[interface{}]int, len(xs))
+	for _, x := range xs {
+		counts[x]++
+	}
+	for _, y := range ys {
+		if counts[y] == 0 {
+			return false
+		}
+		counts[y]--
+	}
+	return true
+}
+
+// peekK returns up to k of the smallest elements of h in ascending order,
+// popping them from a clone if h implements Cloner, and otherwise from h
+// itself before pushing them back.
+func peekK(h Interface, k int) []interface{} {
+	if c := Clone(h); c != nil {
+		return popK(c, k)
+	}
+	xs := popK(h, k)
+	pushAll(h, xs)
+	return xs
+}
+
+// popK pops up to k elements from the min end of h.
+func popK(h Interface, k int) []interface{} {
+	if n := h.Len(); k > n {
+		k = n
+	}
+	if k <= 0 {
+		return nil
+	}
+	xs := make([]interface{}, 0, k)
+	for len(xs) < k {
+		xs = append(xs, Pop(h))
+	}
+	return xs
+}
+
+// pushAll pushes each element of xs onto h.
+func pushAll(h Interface, xs []interface{}) {
+	for _, x := range xs {
+		Push(h, x)
+	}
+}
+
+// Select returns the k-th smallest element of h, counting from 0, without
+// removing it. It panics if k is not in [0, h.Len()). Select pops elements
+// from whichever end of the heap is closer to k. If h implements Cloner, it
+// pops from a clone and h is left untouched; otherwise it pops from h and
+// pushes the elements back afterwards, so h holds the same elements as
+// before, though possibly in a different arrangement.
+// The complexity is O(m log n) where n = h.Len() and m = min(k+1, n-k),
+// plus the cost of Clone.
+func Select(h Interface, k int) interface{} {
+	checkIndex("Select", h, k)
+	n := h.Len()
+	c := Clone(h)
+	restore := c == nil
+	if restore {
+		c = h
+	}
+	if k+1 <= n-k {
+		xs := popK(c, k+1)
+		if restore {
+			pushAll(h, xs)
+		}
+		return xs[k]
+	}
+	xs := PopMaxN(c, n-k)
+	if restore {
+		pushAll(h, xs)
+	}
+	return xs[n-k-1]
+}
+
+// SecondMin returns the second smallest element of h without removing it, or
+// false if h has fewer than two elements. The candidates examined are the
+// children and grandchildren of the root (indices 1 through 6), since the
+// smallest element of the root's subtrees is always among them.
+// The complexity is O(1).
+func SecondMin(h Interface) (interface{}, bool) {
+	n := h.Len()
+	if n < 2 {
+		return nil, false
+	}
+	m := 1
+	for i := 2; i < n && i <= rchild(rchild(0)); i++ {
+		if h.Less(i, m) {
+			m = i
+		}
+	}
+	return element(h, m), true
+}
+
+// SecondMax returns the second largest element of h without removing it, or
+// false if h has fewer than two elements. With the maximum at index m, the
+// candidates examined are the root, the other child of the root, and the
+// children and grandchildren of m.
+// The complexity is O(1).
+func SecondMax(h Interface) (interface{}, bool) {
+	n := h.Len()
+	if n < 2 {
+		return nil, false
+	}
+	max := maxIndex(h)
+	m := -1
+	consider := func(i int) {
+		if i != max && i < n && (m < 0 || h.Less(m, i)) {
+			m = i
+		}
+	}
+	consider(0)
+	consider(lchild(0))
+	consider(rchild(0))
+	for c := lchild(max); c <= rchild(max); c++ {
+		consider(c)
+		consider(lchild(c))
+		consider(rchild(c))
+	}
+	return element(h, m), true
+}
+
+// maxIndex returns the index of the maximum element of the non-empty heap h.
+func maxIndex(h Interface) int {
+	n := h.Len()
+	i := 0
+	l := lchild(0)
+	if l < n && !h.Less(l, i) {
+		i = l
+	}
+	r := rchild(0)
+	if r < n && !h.Less(r, i) {
+		i = r
+	}
+	return i
+}
+
+// element returns the element at index i, leaving h as it was. It uses At if
+// h implements Indexer, and otherwise moves the element to the end, pops it,
+// pushes it back and restores its position.
+func element(h Interface, i int) interface{} {
+	if a, ok := h.(Indexer); ok {
+		return a.At(i)
+	}
+	n := h.Len() - 1
+	h.Swap(i, n)
+	x := h.Pop()
+	h.Push(x)
+	h.Swap(i, n)
+	return x
+}
+
+// MinMaxRange returns the indices of the smallest and largest elements among
+// indices [lo, hi) of h. The range is not assumed to be heap-ordered; every
+// element in it is examined. It returns -1, -1 if the range is empty.
+// The complexity is O(hi-lo).
+func MinMaxRange(h Interface, lo, hi int) (minIdx, maxIdx int) {
+	if lo >= hi {
+		return -1, -1
+	}
+	minIdx, maxIdx = lo, lo
+	for i := lo + 1; i < hi; i++ {
+		if h.Less(i, minIdx) {
+			minIdx = i
+		}
+		if h.Less(maxIdx, i) {
+			maxIdx = i
+		}
+	}
+	return minIdx, maxIdx
+}
+
+// MinCount returns the number of elements of h that are equal to the minimum,
+// that is, not greater than it according to Less. Copies of the minimum can
+// be anywhere in the heap, so this is O(n) in the worst case, but a subtree is
+// skipped when its root is on a min level and greater than the minimum.
+func MinCount(h Interface) int {
+	if h.Len() == 0 {
+		return 0
+	}
+	eq := func(i int) bool { return !h.Less(0, i) }
+	beyond := func(i int) bool { return h.Less(0, i) }
+	return countEqual(h, 0, eq, beyond, true)
+}
+
+// MaxCount returns the number of elements of h that are equal to the maximum,
+// that is, not less than it according to Less. Like MinCount it is O(n) in
+// the worst case, but a subtree is skipped when its root is on a max level and
+// less than the maximum.
+func MaxCount(h Interface) int {
+	if h.Len() == 0 {
+		return 0
+	}
+	m := maxIndex(h)
+	eq := func(i int) bool { return !h.Less(i, m) }
+	beyond := func(i int) bool { return h.Less(i, m) }
+	return countEqual(h, 0, eq, beyond, false)
+}
+
+// countEqual counts the elements of the subtree rooted at i for which eq
+// returns true. A subtree is skipped when its root is on a min level (if
+// onMin) or max level (if !onMin) and beyond returns true for it, meaning the
+// root is strictly past the extreme, as all its descendants then are too.
+func countEqual(h Interface, i int, eq, beyond func(i int) bool, onMin bool) int {
+	if i >= h.Len() {
+		return 0
+	}
+	if isMinLevel(i) == onMin && beyond(i) {
+		return 0
+	}
+	count := 0
+	if eq(i) {
+		count++
+	}
+	return count + countEqual(h, lchild(i), eq, beyond, onMin) + countEqual(h, rchild(i), eq, beyond, onMin)
+}
+
+// Extremes returns the indices of the minimum and maximum elements of h, or
+// false if h is empty. The two indices are equal if h has one element.
+//
+// After changing the elements at both indices, call Fix on maxIdx first and
+// then on minIdx (only once if they are equal).
+// The complexity is O(1).
+func Extremes(h Interface) (minIdx, maxIdx int, ok bool) {
+	if h.Len() == 0 {
+		return -1, -1, false
+	}
+	return 0, maxIndex(h), true
+}
+
+// Height returns the number of levels in h: 0 for an empty heap, and
+// floor(log2(n))+1 otherwise.
+func Height(h Interface) int {
+	n := h.Len()
+	if n == 0 {
+		return 0
+	}
+	return level(n-1) + 1
+}
+
+// Balance returns the fraction of the slots in the last level of h that are
+// filled, in (0, 1], or 0 for an empty heap. A heap is always a complete
+// binary tree, so its shape is determined by Len alone; Balance is 1 when h
+// has exactly 2^k-1 elements.
+func Balance(h Interface) float64 {
+	n := h.Len()
+	if n == 0 {
+		return 0
+	}
+	slots := 1 << level(n-1)
+	filled := n - (slots - 1)
+	return float64(filled) / float64(slots)
+}
+
+// MinRunLength returns the number of elements of h that eq reports equal to
+// the minimum. eq(i, j) reports whether the elements at i and j are equal; it
+// must only do so for elements that are also equal according to Less. If eq
+// agrees with Less, this is how many consecutive Pops return the current
+// minimum value. Like MinCount it is O(n) in the worst case.
+func MinRunLength(h Interface, eq func(i, j int) bool) int {
+	if h.Len() == 0 {
+		return 0
+	}
+	beyond := func(i int) bool { return h.Less(0, i) }
+	return countEqual(h, 0, func(i int) bool { return eq(0, i) }, beyond, true)
+}
@@ -0,0 +1,321 @@
+package minmaxheap
+
+import (
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestTopKEqual(t *testing.T) {
+	a := &myHeap{5, 1, 3, 9, 7}
+	b := &myHeap{3, 1, 5, 2, 0, 100}
+	Init(a)
+	Init(b)
+
+	if TopKEqual(a, b, 3) {
+		t.Errorf("TopKEqual(a, b, 3) = true; want false")
+	}
+
+	c := &myHeap{1, 3, 5, 100, 8}
+	Init(c)
+	if !TopKEqual(a, c, 3) {
+		t.Errorf("TopKEqual(a, c, 3) = false; want true")
+	}
+	if TopKEqual(a, c, 4) {
+		t.Errorf("TopKEqual(a, c, 4) = true; want false")
+	}
+	if a.Len() != 5 || c.Len() != 5 {
+		t.Fatalf("TopKEqual changed Len: a=%d c=%d", a.Len(), c.Len())
+	}
+	a.verify(t, 0)
+	c.verify(t, 0)
+}
+
+func TestTopKEqualTies(t *testing.T) {
+	// both pop {1, 1} first but in a different order
+	a := &keyedHeap{{1, 1}, {2, 3}, {1, 2}}
+	b := &keyedHeap{{1, 2}, {2, 3}, {1, 1}}
+	if Pop(&keyedHeap{{1, 1}, {2, 3}, {1, 2}}) == Pop(&keyedHeap{{1, 2}, {2, 3}, {1, 1}}) {
+		t.Fatal("test heaps pop ties in the same order")
+	}
+	if !TopKEqual(a, b, 2) {
+		t.Error("TopKEqual of the same ties in another order = false; want true")
+	}
+	if TopKEqual(a, &keyedHeap{{1, 1}, {2, 3}, {1, 4}}, 2) {
+		t.Error("TopKEqual of different ties = true; want false")
+	}
+}
+
+func TestTopKEqualClone(t *testing.T) {
+	rng := newTestRand(t)
+
+	a, b := new(myHeap), new(myHeap)
+	for i := 0; i < 50; i++ {
+		x := rng.Intn(20)
+		Push(a, x)
+		Push(b, x)
+	}
+	before := append(myHeap(nil), *a...)
+	if !TopKEqual(a, b, 30) {
+		t.Error("TopKEqual of equal heaps = false; want true")
+	}
+	if !slices.Equal(*a, before) {
+		t.Errorf("TopKEqual rearranged a Cloner: %v; want %v", *a, before)
+	}
+}
+
+func TestTopKEqualShort(t *testing.T) {
+	a := &myHeap{2, 1}
+	b := &myHeap{1, 2, 3}
+	Init(a)
+	Init(b)
+
+	if TopKEqual(a, b, 3) {
+		t.Errorf("TopKEqual with 2 and 3 elements, k=3 = true; want false")
+	}
+	if !TopKEqual(a, b, 2) {
+		t.Errorf("TopKEqual(a, b, 2) = false; want true")
+	}
+
+	c := &myHeap{1, 2}
+	Init(c)
+	if !TopKEqual(a, c, 10) {
+		t.Errorf("TopKEqual of equal short heaps, k=10 = false; want true")
+	}
+	if !TopKEqual(new(myHeap), new(myHeap), 1) {
+		t.Errorf("TopKEqual of empty heaps = false; want true")
+	}
+}
+
+func TestSecondMinMax(t *testing.T) {
+	rng := newTestRand(t)
+
+	for n := 0; n < 100; n++ {
+		h := new(myHeap)
+		for i := 0; i < n; i++ {
+			*h = append(*h, rng.Intn(n+1))
+		}
+		Init(h)
+
+		before := append(myHeap(nil), *h...)
+		sorted := append([]int(nil), *h...)
+		sort.Ints(sorted)
+
+		x, ok := SecondMin(h)
+		if ok != (n >= 2) {
+			t.Fatalf("n=%d: SecondMin ok = %v", n, ok)
+		}
+		if ok && x.(int) != sorted[1] {
+			t.Errorf("n=%d: SecondMin = %d; want %d", n, x, sorted[1])
+		}
+
+		x, ok = SecondMax(h)
+		if ok != (n >= 2) {
+			t.Fatalf("n=%d: SecondMax ok = %v", n, ok)
+		}
+		if ok && x.(int) != sorted[n-2] {
+			t.Errorf("n=%d: SecondMax = %d; want %d", n, x, sorted[n-2])
+		}
+		for i := range before {
+			if (*h)[i] != before[i] {
+				t.Fatalf("n=%d: heap changed at [%d]: %d; want %d", n, i, (*h)[i], before[i])
+			}
+		}
+	}
+}
+
+func TestMinMaxRange(t *testing.T) {
+	h := &myHeap{4, 9, 1, 7, 3, 8, 2}
+
+	for _, tc := range []struct {
+		lo, hi           int
+		wantMin, wantMax int
+	}{
+		{0, 7, 2, 1},
+		{2, 5, 2, 3},
+		{3, 4, 3, 3},
+		{4, 7, 6, 5},
+		{5, 5, -1, -1},
+	} {
+		min, max := MinMaxRange(h, tc.lo, tc.hi)
+		if min != tc.wantMin || max != tc.wantMax {
+			t.Errorf("MinMaxRange(%d, %d) = %d, %d; want %d, %d",
+				tc.lo, tc.hi, min, max, tc.wantMin, tc.wantMax)
+		}
+	}
+}
+
+func TestMinMaxCount(t *testing.T) {
+	rng := newTestRand(t)
+
+	for n := 0; n < 200; n++ {
+		h := new(myHeap)
+		for i := 0; i < n; i++ {
+			Push(h, rng.Intn(4)) // heavy duplication
+		}
+
+		wantMin, wantMax := 0, 0
+		if n > 0 {
+			sorted := append([]int(nil), *h...)
+			sort.Ints(sorted)
+			for _, x := range sorted {
+				if x == sorted[0] {
+					wantMin++
+				}
+				if x == sorted[n-1] {
+					wantMax++
+				}
+			}
+		}
+
+		if got := MinCount(h); got != wantMin {
+			t.Errorf("n=%d: MinCount = %d; want %d", n, got, wantMin)
+		}
+		if got := MaxCount(h); got != wantMax {
+			t.Errorf("n=%d: MaxCount = %d; want %d", n, got, wantMax)
+		}
+	}
+
+	h := &myHeap{7, 7, 7, 7, 7}
+	if MinCount(h) != 5 || MaxCount(h) != 5 {
+		t.Errorf("all equal: MinCount = %d, MaxCount = %d; want 5, 5", MinCount(h), MaxCount(h))
+	}
+}
+
+func TestExtremes(t *testing.T) {
+	rng := newTestRand(t)
+
+	if _, _, ok := Extremes(new(myHeap)); ok {
+		t.Fatal("Extremes of empty heap returned ok")
+	}
+
+	for n := 1; n < 50; n++ {
+		h := new(myHeap)
+		for i := 0; i < n; i++ {
+			Push(h, rng.Intn(100))
+		}
+		sorted := append([]int(nil), *h...)
+		sort.Ints(sorted)
+
+		minIdx, maxIdx, ok := Extremes(h)
+		if !ok || (*h)[minIdx] != sorted[0] || (*h)[maxIdx] != sorted[n-1] {
+			t.Fatalf("n=%d: Extremes = %d, %d, %v", n, minIdx, maxIdx, ok)
+		}
+
+		// update both ends at once
+		(*h)[minIdx] = rng.Intn(120) - 10
+		(*h)[maxIdx] = rng.Intn(120) - 10
+		Fix(h, maxIdx)
+		if minIdx != maxIdx {
+			Fix(h, minIdx)
+		}
+		h.verify(t, 0)
+	}
+}
+
+func TestHeightBalance(t *testing.T) {
+	for _, tc := range []struct {
+		n       int
+		height  int
+		balance float64
+	}{
+		{0, 0, 0},
+		{1, 1, 1},
+		{2, 2, 0.5},
+		{3, 2, 1},
+		{4, 3, 0.25},
+		{7, 3, 1},
+		{8, 4, 0.125},
+		{12, 4, 0.625},
+		{1023, 10, 1},
+		{1024, 11, 1.0 / 1024},
+	} {
+		h := make(myHeap, tc.n)
+		if got := Height(&h); got != tc.height {
+			t.Errorf("Height(n=%d) = %d; want %d", tc.n, got, tc.height)
+		}
+		if tc.n > 0 && Height(&h) != level(tc.n-1)+1 {
+			t.Errorf("Height(n=%d) does not match level of the last index", tc.n)
+		}
+		if got := Balance(&h); got != tc.balance {
+			t.Errorf("Balance(n=%d) = %v; want %v", tc.n, got, tc.balance)
+		}
+	}
+}
+
+func TestMinRunLength(t *testing.T) {
+	h := new(keyedHeap)
+	for id := 0; id < 100; id++ {
+		Push(h, keyed{key: id % 3, id: id % 2})
+	}
+
+	// same key and id as the minimum
+	n := MinRunLength(h, func(i, j int) bool { return (*h)[i] == (*h)[j] })
+	want := 0
+	for _, k := range *h {
+		if k == (*h)[0] {
+			want++
+		}
+	}
+	if n != want {
+		t.Errorf("MinRunLength = %d; want %d", n, want)
+	}
+
+	for i := 0; i < n; i++ {
+		if x := Pop(h).(keyed); x.key != 0 {
+			t.Fatalf("pop %d = %v; want key 0", i, x)
+		}
+	}
+
+	if n := MinRunLength(new(myHeap), func(i, j int) bool { return true }); n != 0 {
+		t.Errorf("MinRunLength(empty) = %d", n)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	rng := newTestRand(t)
+
+	for _, n := range []int{1, 2, 3, 10, 101} {
+		h := new(myHeap)
+		var sorted []int
+		for i := 0; i < n; i++ {
+			x := rng.Intn(2 * n)
+			Push(h, x)
+			sorted = append(sorted, x)
+		}
+		sort.Ints(sorted)
+
+		before := append(myHeap(nil), *h...)
+		for k := 0; k < n; k++ {
+			if x := Select(h, k).(int); x != sorted[k] {
+				t.Fatalf("n=%d: Select(%d) = %d; want %d", n, k, x, sorted[k])
+			}
+		}
+		if !slices.Equal(*h, before) {
+			t.Errorf("n=%d: Select rearranged a Cloner: %v; want %v", n, *h, before)
+		}
+
+		// without Cloner, Select pops from h and pushes the elements back
+		plain := struct{ Interface }{h}
+		for k := 0; k < n; k++ {
+			if x := Select(plain, k).(int); x != sorted[k] {
+				t.Fatalf("n=%d: Select(%d) without Cloner = %d; want %d", n, k, x, sorted[k])
+			}
+			if h.Len() != n {
+				t.Fatalf("n=%d: Len() = %d after Select(%d)", n, h.Len(), k)
+			}
+			h.verify(t, 0)
+		}
+	}
+
+	for _, k := range []int{-1, 3} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Select(%d) of 3 elements did not panic", k)
+				}
+			}()
+			Select(&myHeap{1, 3, 2}, k)
+		}()
+	}
+}
@@ -0,0 +1,32 @@
+package minmaxheap
+
+// ReadOnlyHeap is a view of a heap that offers no way to add or remove
+// elements.
+type ReadOnlyHeap interface {
+	// Len returns the number of elements in the heap.
+	Len() int
+	// Peek returns the minimum element. It panics if the heap is empty.
+	Peek() interface{}
+	// PeekMax returns the maximum element. It panics if the heap is empty.
+	PeekMax() interface{}
+}
+
+// ReadOnly returns a read-only view of h. The view is not a copy: it reflects
+// later changes made to h directly.
+//
+// Unless h implements Indexer, reading an element through Interface requires
+// moving it to the end, popping it and pushing it back, so Peek and PeekMax
+// briefly rearrange h before restoring it. They must then not run
+// concurrently with other uses of h.
+func ReadOnly(h Interface) ReadOnlyHeap {
+	return readOnly{h}
+}
+
+type readOnly struct {
+	h Interface
+}
+
+func (r readOnly) Len() int { return r.h.Len() }
+
+func (r readOnly) Peek() interface{}    { return PeekMin(r.h) }
+func (r readOnly) PeekMax() interface{} { return PeekMax(r.h) }
@@ -0,0 +1,34 @@
+package minmaxheap
+
+import "testing"
+
+func TestReadOnly(t *testing.T) {
+	h := &myHeap{5, 3, 8}
+	Init(h)
+
+	ro := ReadOnly(h)
+	if _, ok := ro.(Interface); ok {
+		t.Fatal("read-only view implements Interface")
+	}
+	if ro.Len() != 3 || ro.Peek().(int) != 3 || ro.PeekMax().(int) != 8 {
+		t.Fatalf("view = Len %d, Peek %v, PeekMax %v", ro.Len(), ro.Peek(), ro.PeekMax())
+	}
+
+	// changes to the heap show through the view
+	Push(h, 1)
+	Push(h, 10)
+	if ro.Len() != 5 || ro.Peek().(int) != 1 || ro.PeekMax().(int) != 10 {
+		t.Fatalf("view after Push = Len %d, Peek %v, PeekMax %v", ro.Len(), ro.Peek(), ro.PeekMax())
+	}
+	h.verify(t, 0)
+
+	for h.Len() > 0 {
+		Pop(h)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Peek on an empty view did not panic")
+		}
+	}()
+	ro.Peek()
+}
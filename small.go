@@ -0,0 +1,104 @@
+package minmaxheap
+
+import "cmp"
+
+// SmallHeap is a double-ended priority queue for a handful of elements,
+// ordered by a less function. It keeps its elements unordered and finds the
+// minimum or maximum by a linear scan, so Push is a plain append and there is
+// no sifting to do. Up to about eight elements this is faster than Heap, whose
+// sifts cost more than they save at these sizes; see BenchmarkSmall. Beyond
+// that, the O(n) scans lose to Heap's O(log n) sifts and Heap should be used
+// instead.
+type SmallHeap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+// NewSmall returns an empty small heap ordered by less.
+func NewSmall[T any](less func(a, b T) bool) *SmallHeap[T] {
+	return &SmallHeap[T]{less: less}
+}
+
+// NewSmallOrdered returns an empty small heap ordered by the natural <
+// ordering of T.
+func NewSmallOrdered[T cmp.Ordered]() *SmallHeap[T] {
+	return NewSmall(cmp.Less[T])
+}
+
+// Len returns the number of elements in the heap.
+func (h *SmallHeap[T]) Len() int {
+	return len(h.items)
+}
+
+// Push pushes the element x onto the heap.
+// The complexity is O(1).
+func (h *SmallHeap[T]) Push(x T) {
+	h.items = append(h.items, x)
+}
+
+// PopMin removes and returns the minimum element from the heap. It panics if
+// the heap is empty.
+// The complexity is O(n) where n = h.Len().
+func (h *SmallHeap[T]) PopMin() T {
+	return h.removeAt(h.minIndex("PopMin"))
+}
+
+// PopMax removes and returns the maximum element from the heap. It panics if
+// the heap is empty.
+// The complexity is O(n) where n = h.Len().
+func (h *SmallHeap[T]) PopMax() T {
+	return h.removeAt(h.maxIndex("PopMax"))
+}
+
+// PeekMin returns the minimum element without removing it. It panics if the
+// heap is empty.
+// The complexity is O(n) where n = h.Len().
+func (h *SmallHeap[T]) PeekMin() T {
+	return h.items[h.minIndex("PeekMin")]
+}
+
+// PeekMax returns the maximum element without removing it. It panics if the
+// heap is empty.
+// The complexity is O(n) where n = h.Len().
+func (h *SmallHeap[T]) PeekMax() T {
+	return h.items[h.maxIndex("PeekMax")]
+}
+
+// minIndex returns the index of the first minimum element, panicking with
+// the name of op if the heap is empty.
+func (h *SmallHeap[T]) minIndex(op string) int {
+	checkNotEmpty(op, len(h.items))
+	m := 0
+	for i := 1; i < len(h.items); i++ {
+		if h.less(h.items[i], h.items[m]) {
+			m = i
+		}
+	}
+	return m
+}
+
+// maxIndex returns the index of the first maximum element, panicking with
+// the name of op if the heap is empty.
+func (h *SmallHeap[T]) maxIndex(op string) int {
+	checkNotEmpty(op, len(h.items))
+	m := 0
+	for i := 1; i < len(h.items); i++ {
+		if h.less(h.items[m], h.items[i]) {
+			m = i
+		}
+	}
+	return m
+}
+
+// removeAt removes and returns the element at index i, moving the last
+// element into its place.
+func (h *SmallHeap[T]) removeAt(i int) T {
+	items := h.items
+	n := len(items) - 1
+	x := items[i]
+	items[i] = items[n]
+	var zero T
+	items[n] = zero
+	h.items = items[:n]
+	return x
+}
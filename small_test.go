@@ -0,0 +1,102 @@
+package minmaxheap
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func TestSmallHeap(t *testing.T) {
+	rng := newTestRand(t)
+
+	for n := 1; n <= 10; n++ {
+		h := NewSmallOrdered[int]()
+		var want []int
+		for i := 0; i < n; i++ {
+			x := rng.Intn(n)
+			h.Push(x)
+			want = append(want, x)
+		}
+		sort.Ints(want)
+
+		for lo, hi := 0, n-1; h.Len() > 0; {
+			if x := h.PeekMin(); x != want[lo] {
+				t.Fatalf("n=%d: PeekMin = %d; want %d", n, x, want[lo])
+			}
+			if x := h.PeekMax(); x != want[hi] {
+				t.Fatalf("n=%d: PeekMax = %d; want %d", n, x, want[hi])
+			}
+			if rng.Intn(2) == 0 {
+				if x := h.PopMin(); x != want[lo] {
+					t.Fatalf("n=%d: PopMin = %d; want %d", n, x, want[lo])
+				}
+				lo++
+			} else {
+				if x := h.PopMax(); x != want[hi] {
+					t.Fatalf("n=%d: PopMax = %d; want %d", n, x, want[hi])
+				}
+				hi--
+			}
+			if h.Len() != hi-lo+1 {
+				t.Fatalf("n=%d: Len() = %d; want %d", n, h.Len(), hi-lo+1)
+			}
+		}
+	}
+}
+
+func TestSmallHeapEmpty(t *testing.T) {
+	h := NewSmallOrdered[int]()
+	for name, op := range map[string]func(){
+		"PopMin":  func() { h.PopMin() },
+		"PopMax":  func() { h.PopMax() },
+		"PeekMin": func() { h.PeekMin() },
+		"PeekMax": func() { h.PeekMax() },
+	} {
+		func() {
+			defer func() {
+				want := "minmaxheap: " + name + " on empty heap"
+				if r := recover(); r != want {
+					t.Errorf("%s on an empty heap panicked with %v; want %q", name, r, want)
+				}
+			}()
+			op()
+		}()
+	}
+}
+
+// BenchmarkSmall compares SmallHeap with Heap at the tiny sizes SmallHeap is
+// meant for. Both are generic over int and ordered by the same less function,
+// so only the algorithms differ.
+func BenchmarkSmall(b *testing.B) {
+	less := func(a, b int) bool { return a < b }
+	for n := 2; n <= 8; n++ {
+		b.Run(fmt.Sprintf("Heap/%d", n), func(b *testing.B) {
+			h := New(less)
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < n; j++ {
+					h.Push((j * 7) % n)
+				}
+				for h.Len() > 0 {
+					h.PopMin()
+					if h.Len() > 0 {
+						h.PopMax()
+					}
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("SmallHeap/%d", n), func(b *testing.B) {
+			h := NewSmall(less)
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < n; j++ {
+					h.Push((j * 7) % n)
+				}
+				for h.Len() > 0 {
+					h.PopMin()
+					if h.Len() > 0 {
+						h.PopMax()
+					}
+				}
+			}
+		})
+	}
+}
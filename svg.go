@@ -0,0 +1,154 @@
+package minmaxheap
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// FormatSVG returns an SVG drawing of h as a tree, with min levels in blue and
+// max levels in pink, each node labeled with its value, formatted with %v, and
+// its index. The node at index highlight gets a red outline; an index out of
+// range highlights nothing. To keep the drawing a usable size, heaps deeper
+// than 7 levels are cut off after the top 7, with a note saying so.
+//
+// Unless h implements Indexer, values are read through Pop and Push, so h is
+// briefly rearranged, though it is left as it was. It need not be a valid heap, so FormatSVG can be used to
+// inspect one that fails Verify.
+// The complexity is O(n) where n = h.Len().
+func FormatSVG(h Interface, highlight int) string {
+	var buf strings.Builder
+	_ = WriteSVG(&buf, h, highlight)
+	return buf.String()
+}
+
+// WriteSVG is like FormatSVG, but writes the drawing to w. It returns the
+// first error from w, if any.
+func WriteSVG(w io.Writer, h Interface, highlight int) error {
+	bw := bufio.NewWriter(w)
+	n := h.Len()
+
+	// Determine SVG parameters based on heap size
+	nodeDiameter, levelHeight := 40, 80
+	if n > 127 { // Large heap (8+ levels)
+		nodeDiameter, levelHeight = 24, 50
+	} else if n > 31 { // Medium heap (6-7 levels)
+		nodeDiameter, levelHeight = 30, 60
+	}
+	const leftMargin, topMargin = 10, 20
+
+	// Calculate the total width needed for the tree
+	levels := level(n)
+
+	// For very large heaps, limit the width by not showing all levels
+	maxLevelsToShow := levels
+	if levels > 7 {
+		maxLevelsToShow = 7 // Only show top 7 levels for very large heaps
+	}
+
+	maxNodesInLevel := 1 << maxLevelsToShow // Maximum nodes in the last level we'll show
+	totalWidth := maxNodesInLevel*(nodeDiameter*2) + leftMargin*2
+	totalHeight := (maxLevelsToShow+1)*levelHeight + topMargin*2
+
+	fmt.Fprintf(bw, "<svg width=\"%d\" height=\"%d\" xmlns=\"http://www.w3.org/2000/svg\">\n",
+		totalWidth, totalHeight)
+	fmt.Fprintf(bw, "  <title>MinMaxHeap Visualization (%d nodes)</title>\n", n)
+
+	// Style definitions
+	bw.WriteString(`  <style>
+    .node-min { fill: lightblue; stroke: #333; stroke-width: 2; }
+    .node-max { fill: lightpink; stroke: #333; stroke-width: 2; }
+    .node-highlight { stroke: red; stroke-width: 3; }
+    .node-text { font-family: Arial; font-size: 14px; text-anchor: middle; dominant-baseline: middle; }
+    .node-index { font-family: Arial; font-size: 10px; text-anchor: middle; fill: #666; }
+    .edge { stroke: #666; stroke-width: 1.5; fill: none; }
+    .legend { font-family: Arial; font-size: 12px; }
+  </style>
+`)
+
+	// Add a legend
+	bw.WriteString(`  <!-- Legend -->
+  <rect x="10" y="10" width="15" height="15" class="node-min" />
+  <text x="30" y="22" class="legend">Min Level</text>
+  <rect x="100" y="10" width="15" height="15" class="node-max" />
+  <text x="120" y="22" class="legend">Max Level</text>
+`)
+
+	// Calculate positions for each node
+	nodesCount := n
+	if maxLevelsToShow < levels {
+		// Calculate how many nodes we're showing if we limited levels
+		nodesCount = (1 << (maxLevelsToShow + 1)) - 1
+		if nodesCount > n {
+			nodesCount = n
+		}
+	}
+
+	type point struct{ x, y int }
+	pos := make([]point, nodesCount)
+	levelWidth := totalWidth - (leftMargin * 2)
+	for i := range pos {
+		lev := level(i)
+		nodesInLevel := 1 << lev
+		posInLevel := i - (nodesInLevel - 1)
+
+		// centered in its segment of the level
+		segmentWidth := levelWidth / nodesInLevel
+		pos[i] = point{
+			x: leftMargin + (posInLevel * segmentWidth) + (segmentWidth / 2),
+			y: topMargin + (lev * levelHeight) + (nodeDiameter / 2),
+		}
+	}
+
+	// Draw edges first (so they'll be behind nodes)
+	bw.WriteString("  <!-- Edges connecting nodes -->\n")
+	for i := range pos {
+		if level(i) >= maxLevelsToShow {
+			continue // Skip drawing edges from nodes in the last level
+		}
+		for _, c := range []int{lchild(i), rchild(i)} {
+			if c < nodesCount {
+				fmt.Fprintf(bw, "  <path class=\"edge\" d=\"M%d,%d C%d,%d %d,%d %d,%d\" />\n",
+					pos[i].x, pos[i].y+(nodeDiameter/2),
+					pos[i].x, pos[i].y+levelHeight/3,
+					pos[c].x, pos[c].y-levelHeight/3,
+					pos[c].x, pos[c].y-(nodeDiameter/2))
+			}
+		}
+	}
+
+	fontSize := 10
+	if nodeDiameter < 30 {
+		fontSize = 8 // Smaller font for smaller nodes
+	}
+
+	// Draw all nodes
+	bw.WriteString("  <!-- Nodes -->\n")
+	for i, p := range pos {
+		nodeClass := "node-min"
+		if !isMinLevel(i) {
+			nodeClass = "node-max"
+		}
+		if i == highlight {
+			nodeClass += " node-highlight"
+		}
+
+		fmt.Fprintf(bw, "  <circle cx=\"%d\" cy=\"%d\" r=\"%d\" class=\"%s\" />\n",
+			p.x, p.y, nodeDiameter/2, nodeClass)
+		fmt.Fprintf(bw, "  <text x=\"%d\" y=\"%d\" class=\"node-text\">%s</text>\n",
+			p.x, p.y, html.EscapeString(fmt.Sprint(element(h, i))))
+		fmt.Fprintf(bw, "  <text x=\"%d\" y=\"%d\" dy=\"%d\" class=\"node-index\" font-size=\"%d\">[%d]</text>\n",
+			p.x, p.y, -nodeDiameter/2-2, fontSize, i)
+	}
+
+	// If we limited the display, add a note
+	if maxLevelsToShow < levels {
+		fmt.Fprintf(bw, "  <text x=\"%d\" y=\"%d\" class=\"legend\">Note: Only showing %d of %d levels. Total nodes: %d</text>\n",
+			totalWidth/2, totalHeight-20, maxLevelsToShow, levels, n)
+	}
+
+	bw.WriteString("</svg>")
+	return bw.Flush()
+}
@@ -0,0 +1,90 @@
+package minmaxheap
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFormatSVG(t *testing.T) {
+	h := &myHeap{1, 9, 8, 2, 3, 4, 5}
+	before := append(myHeap(nil), *h...)
+
+	svg := FormatSVG(h, 1)
+	if !slices.Equal(*h, before) {
+		t.Errorf("FormatSVG changed the heap to %v", *h)
+	}
+	if !strings.HasPrefix(svg, "<svg ") || !strings.HasSuffix(svg, "</svg>") {
+		t.Fatalf("FormatSVG is not an svg element:\n%s", svg)
+	}
+	if n := strings.Count(svg, "<circle "); n != 7 {
+		t.Errorf("FormatSVG drew %d nodes; want 7", n)
+	}
+	// one in the legend, and index 2
+	if n := strings.Count(svg, `class="node-max"`); n != 2 {
+		t.Errorf("FormatSVG has %d plain max nodes; want 2", n)
+	}
+	if n := strings.Count(svg, `class="node-max node-highlight"`); n != 1 {
+		t.Errorf("FormatSVG highlighted %d max nodes; want 1", n)
+	}
+	if !strings.Contains(svg, `class="node-text">9</text>`) {
+		t.Errorf("FormatSVG is missing the value 9:\n%s", svg)
+	}
+
+	for _, highlight := range []int{-1, 7, 100} {
+		if svg := FormatSVG(h, highlight); strings.Contains(svg, `node-highlight"`) {
+			t.Errorf("FormatSVG(%d) highlighted a node", highlight)
+		}
+	}
+
+	if svg := FormatSVG(new(myHeap), 0); strings.Contains(svg, "<circle ") {
+		t.Errorf("FormatSVG of an empty heap drew nodes:\n%s", svg)
+	}
+
+	big := new(myHeap)
+	for i := 0; i < 1000; i++ {
+		Push(big, i)
+	}
+	svg = FormatSVG(big, 0)
+	if n := strings.Count(svg, "<circle "); n != 255 {
+		t.Errorf("FormatSVG of 1000 elements drew %d nodes; want 255", n)
+	}
+	if !strings.Contains(svg, "Only showing 7 of 9 levels") {
+		t.Errorf("FormatSVG of 1000 elements has no truncation note")
+	}
+}
+
+func TestFormatSVGEscapes(t *testing.T) {
+	h := &BytesHeap{[]byte("a<b")}
+	if svg := FormatSVG(h, 0); !strings.Contains(svg, "[97 60 98]") {
+		t.Errorf("FormatSVG did not format the value with %%v:\n%s", svg)
+	}
+	s := &stringHeap{"<&>"}
+	if svg := FormatSVG(s, 0); !strings.Contains(svg, ">&lt;&amp;&gt;</text>") {
+		t.Errorf("FormatSVG did not escape the value:\n%s", svg)
+	}
+}
+
+type stringHeap []string
+
+func (h stringHeap) Len() int            { return len(h) }
+func (h stringHeap) Less(i, j int) bool  { return h[i] < h[j] }
+func (h stringHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *stringHeap) Push(x interface{}) { *h = append(*h, x.(string)) }
+func (h *stringHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+type failWriter struct{}
+
+func (failWriter) Write([]byte) (int, error) { return 0, errors.New("write failed") }
+
+func TestWriteSVGError(t *testing.T) {
+	if err := WriteSVG(failWriter{}, &myHeap{1, 2, 3}, 0); err == nil || err.Error() != "write failed" {
+		t.Errorf("WriteSVG = %v; want the writer's error", err)
+	}
+}
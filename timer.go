@@ -0,0 +1,79 @@
+package minmaxheap
+
+import "time"
+
+// TimerQueue holds timers identified by strings, each with a deadline, and
+// releases them in deadline order once they expire. It is built on an
+// IndexedHeap, so a timer can be canceled or rescheduled by id without a
+// search. The zero value is not usable; create one with NewTimerQueue.
+type TimerQueue struct {
+	h   *IndexedHeap[timer]
+	ids map[string]Handle
+}
+
+type timer struct {
+	id string
+	at time.Time
+}
+
+// NewTimerQueue returns an empty TimerQueue.
+func NewTimerQueue() *TimerQueue {
+	return &TimerQueue{
+		h:   NewIndexed(func(a, b timer) bool { return a.at.Before(b.at) }),
+		ids: make(map[string]Handle),
+	}
+}
+
+// Len returns the number of pending timers.
+func (q *TimerQueue) Len() int {
+	return q.h.Len()
+}
+
+// Add schedules the timer id to expire at at. If id is already pending, its
+// deadline is changed to at.
+// The complexity is O(log n) where n = q.Len().
+func (q *TimerQueue) Add(id string, at time.Time) {
+	t := timer{id: id, at: at}
+	if hd, ok := q.ids[id]; ok {
+		q.h.Update(hd, t)
+		return
+	}
+	q.ids[id] = q.h.PushWithHandle(t)
+}
+
+// Cancel removes the timer id and reports whether it was pending.
+// The complexity is O(log n) where n = q.Len().
+func (q *TimerQueue) Cancel(id string) bool {
+	hd, ok := q.ids[id]
+	if !ok {
+		return false
+	}
+	q.h.RemoveHandle(hd)
+	delete(q.ids, id)
+	return true
+}
+
+// Next returns the earliest pending deadline, or false if no timers are
+// pending.
+// The complexity is O(1).
+func (q *TimerQueue) Next() (time.Time, bool) {
+	if q.h.Len() == 0 {
+		return time.Time{}, false
+	}
+	return q.h.PeekMin().at, true
+}
+
+// PopExpired removes the timers whose deadline is at or before now and
+// returns their ids in deadline order. Timers with equal deadlines are
+// returned in no particular order. It returns nil if none have expired.
+// The complexity is O(k log n) where n = q.Len() and k is the number of
+// timers removed.
+func (q *TimerQueue) PopExpired(now time.Time) []string {
+	var ids []string
+	for q.h.Len() > 0 && !q.h.PeekMin().at.After(now) {
+		t := q.h.PopMin()
+		delete(q.ids, t.id)
+		ids = append(ids, t.id)
+	}
+	return ids
+}
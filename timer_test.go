@@ -0,0 +1,89 @@
+package minmaxheap
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTimerQueue(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(s int) time.Time { return base.Add(time.Duration(s) * time.Second) }
+
+	q := NewTimerQueue()
+	if _, ok := q.Next(); ok {
+		t.Fatal("Next of an empty queue reported a deadline")
+	}
+	for _, s := range []int{5, 1, 9, 3, 7, 2} {
+		q.Add(fmt.Sprint("t", s), at(s))
+	}
+	if next, ok := q.Next(); !ok || !next.Equal(at(1)) {
+		t.Fatalf("Next() = %v, %v; want %v", next, ok, at(1))
+	}
+
+	if !q.Cancel("t3") || q.Cancel("t3") || q.Cancel("missing") {
+		t.Fatal("Cancel reported the wrong result")
+	}
+	q.Add("t9", at(4)) // reschedule earlier
+	q.Add("t1", at(8)) // reschedule later
+
+	if ids := q.PopExpired(at(0)); ids != nil {
+		t.Fatalf("PopExpired(0) = %v; want nil", ids)
+	}
+	if ids, want := q.PopExpired(at(5)), []string{"t2", "t9", "t5"}; !slices.Equal(ids, want) {
+		t.Fatalf("PopExpired(5) = %v; want %v", ids, want)
+	}
+	if q.Len() != 2 || q.Cancel("t5") {
+		t.Fatalf("Len() = %d after PopExpired; want 2 with t5 gone", q.Len())
+	}
+	q.Add("t5", at(6)) // an expired id can be added again
+	if ids, want := q.PopExpired(at(100)), []string{"t5", "t7", "t1"}; !slices.Equal(ids, want) {
+		t.Fatalf("PopExpired(100) = %v; want %v", ids, want)
+	}
+	if q.Len() != 0 || len(q.ids) != 0 {
+		t.Fatalf("Len() = %d, %d ids after draining", q.Len(), len(q.ids))
+	}
+}
+
+func TestTimerQueueRandom(t *testing.T) {
+	rng := newTestRand(t)
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	q := NewTimerQueue()
+	pending := map[string]time.Time{}
+	for i := 0; i < 500; i++ {
+		id := fmt.Sprint(rng.Intn(100))
+		switch rng.Intn(4) {
+		case 0:
+			if _, ok := pending[id]; q.Cancel(id) != ok {
+				t.Fatalf("Cancel(%s) disagrees with pending", id)
+			}
+			delete(pending, id)
+		case 1:
+			now := base.Add(time.Duration(rng.Intn(1000)) * time.Millisecond)
+			prev := time.Time{}
+			for _, id := range q.PopExpired(now) {
+				d, ok := pending[id]
+				if !ok || d.After(now) || d.Before(prev) {
+					t.Fatalf("PopExpired(%v) returned %s with deadline %v", now, id, d)
+				}
+				prev = d
+				delete(pending, id)
+			}
+			for id, d := range pending {
+				if !d.After(now) {
+					t.Fatalf("%s with deadline %v not expired at %v", id, d, now)
+				}
+			}
+		default:
+			d := base.Add(time.Duration(rng.Intn(1000)) * time.Millisecond)
+			q.Add(id, d)
+			pending[id] = d
+		}
+		if q.Len() != len(pending) {
+			t.Fatalf("Len() = %d; want %d", q.Len(), len(pending))
+		}
+		q.h.verify(t)
+	}
+}
@@ -0,0 +1,30 @@
+package minmaxheap
+
+// Uint8Heap is a min-max heap of uint8 values, for small bounded priorities.
+// It stores one byte per element, an eighth of the memory of a heap of ints,
+// and boxing a uint8 in Push and Pop never allocates. Use a *Uint8Heap with
+// the functions in this package.
+type Uint8Heap []uint8
+
+// Len implements Interface.
+func (h Uint8Heap) Len() int { return len(h) }
+
+// Less implements Interface.
+func (h Uint8Heap) Less(i, j int) bool { return h[i] < h[j] }
+
+// Swap implements Interface.
+func (h Uint8Heap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+// Push implements Interface. x must be a uint8.
+func (h *Uint8Heap) Push(x interface{}) {
+	*h = append(*h, x.(uint8))
+}
+
+// Pop implements Interface.
+func (h *Uint8Heap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
@@ -0,0 +1,55 @@
+package minmaxheap
+
+import "testing"
+
+func TestUint8Heap(t *testing.T) {
+	rng := newTestRand(t)
+
+	const n = 1_000
+	small, ints := new(Uint8Heap), new(myHeap)
+	for i := 0; i < n; i++ {
+		x := rng.Intn(256)
+		Push(small, uint8(x))
+		Push(ints, x)
+	}
+
+	for i := 0; small.Len() > 0; i++ {
+		var got, want int
+		if i%2 == 0 {
+			got, want = int(Pop(small).(uint8)), Pop(ints).(int)
+		} else {
+			got, want = int(PopMax(small).(uint8)), PopMax(ints).(int)
+		}
+		if got != want {
+			t.Fatalf("pop %d = %d; want %d", i, got, want)
+		}
+	}
+	if ints.Len() != 0 {
+		t.Fatalf("int heap has %d elements left", ints.Len())
+	}
+}
+
+func BenchmarkUint8Heap(b *testing.B) {
+	const n = 1_000_000
+
+	b.Run("uint8", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			h := make(Uint8Heap, 0, n)
+			for j := 0; j < n; j++ {
+				h = append(h, uint8(j))
+			}
+			Init(&h)
+		}
+	})
+	b.Run("int", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			h := make(myHeap, 0, n)
+			for j := 0; j < n; j++ {
+				h = append(h, j%256)
+			}
+			Init(&h)
+		}
+	})
+}
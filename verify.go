@@ -0,0 +1,98 @@
+package minmaxheap
+
+import "fmt"
+
+// CheckConservation returns an error unless before and after hold the same
+// multiset of elements according to eq. It is meant for testing operations
+// that must only rearrange elements, such as Init or Fix, by comparing a copy
+// taken before the operation with the heap afterwards. Neither heap is
+// modified.
+// The complexity is O(n²) where n = before.Len().
+func CheckConservation(before, after Interface, eq func(a, b interface{}) bool) error {
+	n := before.Len()
+	if m := after.Len(); m != n {
+		return fmt.Errorf("minmaxheap: element count changed from %d to %d", n, m)
+	}
+
+	remaining := make([]interface{}, n)
+	for i := range remaining {
+		remaining[i] = element(after, i)
+	}
+	for i := 0; i < n; i++ {
+		x := element(before, i)
+		found := false
+		for j, y := range remaining {
+			if eq(x, y) {
+				remaining[j] = remaining[len(remaining)-1]
+				remaining = remaining[:len(remaining)-1]
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("minmaxheap: element %v at [%d] is missing or duplicated", x, i)
+		}
+	}
+	return nil
+}
+
+// Violation describes a broken min-max heap relationship between the element
+// at Parent and the element at Child, which is one of its children or
+// grandchildren. If MinLevel is true, Parent is on a min level and Child is
+// less than it; otherwise Parent is on a max level and Child is greater.
+type Violation struct {
+	Parent, Child int
+	MinLevel      bool
+}
+
+// AllViolations returns every broken relationship between an element and its
+// children and grandchildren, ordered by Parent and then Child. Checking these
+// is sufficient: the heap is valid if and only if the result is empty.
+// The complexity is O(n) where n = h.Len().
+func AllViolations(h Interface) []Violation {
+	var vs []Violation
+	n := h.Len()
+	for i := 0; i < n; i++ {
+		min := isMinLevel(i)
+		l, r := lchild(i), rchild(i)
+		check := func(d int) {
+			if d >= n {
+				return
+			}
+			if min && h.Less(d, i) || !min && h.Less(i, d) {
+				vs = append(vs, Violation{Parent: i, Child: d, MinLevel: min})
+			}
+		}
+		check(l)
+		check(r)
+		for g := lchild(l); g <= rchild(r); g++ {
+			check(g)
+		}
+	}
+	return vs
+}
+
+// Verify returns an error describing the first broken relationship reported
+// by AllViolations, naming the indexes and values involved, or nil if h is a
+// valid min-max heap. Unless h implements Indexer, values are read through Pop
+// and Push, so h is briefly rearranged, though it is left as it was.
+// The complexity is O(n) where n = h.Len().
+func Verify(h Interface) error {
+	vs := AllViolations(h)
+	if len(vs) == 0 {
+		return nil
+	}
+	v := vs[0]
+	rel := "greater than"
+	level := "min"
+	if !v.MinLevel {
+		rel = "less than"
+		level = "max"
+	}
+	more := ""
+	if len(vs) > 1 {
+		more = fmt.Sprintf(" (and %d more violations)", len(vs)-1)
+	}
+	return fmt.Errorf("minmaxheap: element %v at [%d] on a %s level is %s its descendant %v at [%d]%s",
+		element(h, v.Parent), v.Parent, level, rel, element(h, v.Child), v.Child, more)
+}
@@ -0,0 +1,88 @@
+package minmaxheap
+
+import (
+	"slices"
+	"testing"
+)
+
+func intsEqual(a, b interface{}) bool { return a.(int) == b.(int) }
+
+func TestCheckConservation(t *testing.T) {
+	before := &myHeap{1, 2, 2, 3}
+	for _, tc := range []struct {
+		after myHeap
+		ok    bool
+	}{
+		{myHeap{2, 3, 1, 2}, true},
+		{myHeap{1, 2, 3, 3}, false},
+		{myHeap{1, 2, 3}, false},
+		{myHeap{1, 2, 3, 4}, false},
+	} {
+		err := CheckConservation(before, &tc.after, intsEqual)
+		if (err == nil) != tc.ok {
+			t.Errorf("CheckConservation(%v, %v) = %v; want ok=%v", *before, tc.after, err, tc.ok)
+		}
+	}
+}
+
+func TestAllViolations(t *testing.T) {
+	h := new(myHeap)
+	for i := 0; i < 15; i++ {
+		Push(h, i*10)
+	}
+	if vs := AllViolations(h); len(vs) != 0 {
+		t.Fatalf("AllViolations of a valid heap = %v", vs)
+	}
+
+	h = &myHeap{0, 60, 50, 10, 20, 30, 40}
+	if vs := AllViolations(h); len(vs) != 0 {
+		t.Fatalf("AllViolations of a valid heap = %v", vs)
+	}
+
+	(*h)[4] = 70 // greater than its max-level parent
+	(*h)[5] = -5 // less than its min-level grandparent, the root
+	(*h)[2] = 5  // less than its min-level children
+
+	got := AllViolations(h)
+	want := []Violation{
+		{Parent: 0, Child: 5, MinLevel: true},
+		{Parent: 1, Child: 4, MinLevel: false},
+		{Parent: 2, Child: 6, MinLevel: false},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("AllViolations = %v; want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("AllViolations[%d] = %v; want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	rng := newTestRand(t)
+
+	h := new(myHeap)
+	for i := 0; i < 100; i++ {
+		Push(h, rng.Intn(50))
+		if err := Verify(h); err != nil {
+			t.Fatalf("Verify of a valid heap = %v", err)
+		}
+	}
+
+	h = &myHeap{0, 60, 50, 10, 20, 30, 40}
+	(*h)[4] = 70
+	want := "minmaxheap: element 60 at [1] on a max level is less than its descendant 70 at [4]"
+	if err := Verify(h); err == nil || err.Error() != want {
+		t.Errorf("Verify = %v; want %q", err, want)
+	}
+	if !slices.Equal(*h, myHeap{0, 60, 50, 10, 70, 30, 40}) {
+		t.Errorf("Verify changed the heap to %v", *h)
+	}
+
+	(*h)[5] = -5
+	want = "minmaxheap: element 0 at [0] on a min level is greater than its descendant -5 at [5] (and 1 more violations)"
+	if err := Verify(h); err == nil || err.Error() != want {
+		t.Errorf("Verify = %v; want %q", err, want)
+	}
+}